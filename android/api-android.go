@@ -14,6 +14,39 @@ func hCashCreateAccount(secret string) *C.char {
 	return C.CString(account)
 }
 
+//export hCashCreateAccountFromEthKey
+func hCashCreateAccountFromEthKey(input string) *C.char {
+	var data = make([]byte, len(input))
+	copy(data, []byte(input))
+
+	result := client.CreateAccountFromEthKey(string(data))
+	return C.CString(result)
+}
+
+//export hCashEthKeyMessage
+func hCashEthKeyMessage() *C.char {
+	result := client.EthKeyMessage()
+	return C.CString(result)
+}
+
+//export hCashEncodeAddress
+func hCashEncodeAddress(input string) *C.char {
+	var data = make([]byte, len(input))
+	copy(data, []byte(input))
+
+	result := client.EncodeAddress(string(data))
+	return C.CString(result)
+}
+
+//export hCashDecodeAddress
+func hCashDecodeAddress(input string) *C.char {
+	var data = make([]byte, len(input))
+	copy(data, []byte(input))
+
+	result := client.DecodeAddress(string(data))
+	return C.CString(result)
+}
+
 //export hCashSign
 func hCashSign(input string) *C.char {
 	var data = make([]byte, len(input))
@@ -23,6 +56,150 @@ func hCashSign(input string) *C.char {
 	return C.CString(signed)
 }
 
+//export hCashVerifySign
+func hCashVerifySign(input string) *C.char {
+	var data = make([]byte, len(input))
+	copy(data, []byte(input))
+
+	result := client.VerifySign(string(data))
+	return C.CString(result)
+}
+
+//export hCashSignMessage
+func hCashSignMessage(input string) *C.char {
+	var data = make([]byte, len(input))
+	copy(data, []byte(input))
+
+	result := client.SignMessage(string(data))
+	return C.CString(result)
+}
+
+//export hCashVerifyMessage
+func hCashVerifyMessage(input string) *C.char {
+	var data = make([]byte, len(input))
+	copy(data, []byte(input))
+
+	result := client.VerifyMessage(string(data))
+	return C.CString(result)
+}
+
+//export hCashSignLogin
+func hCashSignLogin(input string) *C.char {
+	var data = make([]byte, len(input))
+	copy(data, []byte(input))
+
+	result := client.SignLogin(string(data))
+	return C.CString(result)
+}
+
+//export hCashCreateKeystore
+func hCashCreateKeystore(input string) *C.char {
+	var data = make([]byte, len(input))
+	copy(data, []byte(input))
+
+	result := client.CreateKeystore(string(data))
+	return C.CString(result)
+}
+
+//export hCashImportKeystore
+func hCashImportKeystore(input string) *C.char {
+	var data = make([]byte, len(input))
+	copy(data, []byte(input))
+
+	result := client.ImportKeystore(string(data))
+	return C.CString(result)
+}
+
+//export hCashExportKeystore
+func hCashExportKeystore(input string) *C.char {
+	var data = make([]byte, len(input))
+	copy(data, []byte(input))
+
+	result := client.ExportKeystore(string(data))
+	return C.CString(result)
+}
+
+//export hCashChangeKeystorePassword
+func hCashChangeKeystorePassword(input string) *C.char {
+	var data = make([]byte, len(input))
+	copy(data, []byte(input))
+
+	result := client.ChangeKeystorePassword(string(data))
+	return C.CString(result)
+}
+
+//export hCashNewMnemonic
+func hCashNewMnemonic(input string) *C.char {
+	var data = make([]byte, len(input))
+	copy(data, []byte(input))
+
+	result := client.NewMnemonic(string(data))
+	return C.CString(result)
+}
+
+//export hCashValidateMnemonic
+func hCashValidateMnemonic(input string) *C.char {
+	var data = make([]byte, len(input))
+	copy(data, []byte(input))
+
+	result := client.ValidateMnemonic(string(data))
+	return C.CString(result)
+}
+
+//export hCashDeriveAccount
+func hCashDeriveAccount(input string) *C.char {
+	var data = make([]byte, len(input))
+	copy(data, []byte(input))
+
+	result := client.DeriveAccount(string(data))
+	return C.CString(result)
+}
+
+//export hCashSplitAccount
+func hCashSplitAccount(input string) *C.char {
+	var data = make([]byte, len(input))
+	copy(data, []byte(input))
+
+	result := client.SplitAccount(string(data))
+	return C.CString(result)
+}
+
+//export hCashCombineShares
+func hCashCombineShares(input string) *C.char {
+	var data = make([]byte, len(input))
+	copy(data, []byte(input))
+
+	result := client.CombineShares(string(data))
+	return C.CString(result)
+}
+
+//export hCashCreateInvoice
+func hCashCreateInvoice(input string) *C.char {
+	var data = make([]byte, len(input))
+	copy(data, []byte(input))
+
+	result := client.CreateInvoice(string(data))
+	return C.CString(result)
+}
+
+//export hCashParseInvoice
+func hCashParseInvoice(input string) *C.char {
+	var data = make([]byte, len(input))
+	copy(data, []byte(input))
+
+	result := client.ParseInvoice(string(data))
+	return C.CString(result)
+}
+
+//export hCashVerifyInvoice
+func hCashVerifyInvoice(input string) *C.char {
+	var data = make([]byte, len(input))
+	copy(data, []byte(input))
+
+	result := client.VerifyInvoice(string(data))
+	return C.CString(result)
+}
+
 //export hCashReadBalance
 func hCashReadBalance(param string) int32 {
 	var data = make([]byte, len(param))
@@ -33,6 +210,32 @@ func hCashReadBalance(param string) int32 {
 	return int32(balance)
 }
 
+//export hCashReadBalances
+func hCashReadBalances(param string) *C.char {
+	var data = make([]byte, len(param))
+	copy(data, []byte(param))
+
+	result := client.ReadBalances(string(data))
+	return C.CString(result)
+}
+
+//export hCashDecryptTransfer
+func hCashDecryptTransfer(input string) *C.char {
+	var data = make([]byte, len(input))
+	copy(data, []byte(input))
+
+	result := client.DecryptTransfer(string(data))
+	return C.CString(result)
+}
+
+//export hCashLoadBalanceTable
+func hCashLoadBalanceTable(path string) int32 {
+	var data = make([]byte, len(path))
+	copy(data, []byte(path))
+
+	return int32(client.LoadBalanceTable(string(data)))
+}
+
 //export hCashShuffle
 func hCashShuffle(param string) *C.char {
 	var data = make([]byte, len(param))
@@ -114,4 +317,22 @@ func hCashParseSimulateAccountsData(param string) *C.char {
 	return C.CString(result)
 }
 
+//export hCashVerifyTransfer
+func hCashVerifyTransfer(param string) *C.char {
+	var data = make([]byte, len(param))
+	copy(data, []byte(param))
+
+	result := client.VerifyTransfer(string(data))
+	return C.CString(result)
+}
+
+//export hCashVerifyBurn
+func hCashVerifyBurn(param string) *C.char {
+	var data = make([]byte, len(param))
+	copy(data, []byte(param))
+
+	result := client.VerifyBurn(string(data))
+	return C.CString(result)
+}
+
 func main() {}
@@ -0,0 +1,195 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	types2 "github.com/hpb-project/HCash-SDK/common/types"
+	"github.com/hpb-project/HCash-SDK/core/client"
+)
+
+// The air-gapped commands split a transfer or burn in three steps, only
+// the second one needs the Zether key and runs offline:
+//
+//	hcash request -kind transfer -y <y> -to <friend y> -value 1 -o request.json
+//	hcash prove   -f request.json -keystore alice.json -o response.json
+//	hcash tx      -request request.json -response response.json [-sk <eth key>]
+//
+// Points are given as 0x followed by the 128 hex digits of x||y.
+func airgapCmd(name string, args []string) error {
+	switch name {
+	case "request":
+		return requestCmd(args)
+	case "prove":
+		return proveCmd(args)
+	case "tx":
+		return txCmd(args)
+	}
+	return fmt.Errorf("unknown command %s", name)
+}
+
+func writeOutput(path string, data string) error {
+	if path == "" {
+		fmt.Println(data)
+		return nil
+	}
+	return ioutil.WriteFile(path, []byte(data), 0600)
+}
+
+func parsePoint(s string) (types2.Point, error) {
+	var p types2.Point
+	err := p.UnmarshalText([]byte(s))
+	return p, err
+}
+
+// requestCmd fetches the epoch and the simulated accounts and writes the
+// proof request, it needs no secret.
+func requestCmd(args []string) error {
+	fs := flag.NewFlagSet("request", flag.ExitOnError)
+	kind := fs.String("kind", client.ProofKindTransfer, "transfer or burn")
+	self := fs.String("y", "", "public key of the sending account")
+	to := fs.String("to", "", "public key of the receiver, transfer only")
+	decoys := fs.String("decoys", "", "comma separated public keys of the decoys, transfer only")
+	value := fs.Int("value", 0, "amount to transfer or burn")
+	sender := fs.String("sender", "", "address sending the transaction, required for burn")
+	out := fs.String("o", "", "output file, stdout when empty")
+	fs.Parse(args)
+
+	y, err := parsePoint(*self)
+	if err != nil {
+		return fmt.Errorf("-y: %w", err)
+	}
+	var req = client.ProofRequest{
+		Kind:   *kind,
+		ZSC:    ZSCContract.Hex(),
+		Value:  *value,
+		Sender: *sender,
+		Y:      []types2.Point{y},
+	}
+	if *kind == client.ProofKindTransfer {
+		var shuffleParam client.ShuffleParam
+		shuffleParam.Self = y
+		if shuffleParam.Friend, err = parsePoint(*to); err != nil {
+			return fmt.Errorf("-to: %w", err)
+		}
+		shuffleParam.Decoys = []types2.Point{}
+		for _, d := range strings.Split(*decoys, ",") {
+			if d == "" {
+				continue
+			}
+			decoy, err := parsePoint(d)
+			if err != nil {
+				return fmt.Errorf("-decoys: %w", err)
+			}
+			shuffleParam.Decoys = append(shuffleParam.Decoys, decoy)
+		}
+		sstr, _ := json.Marshal(shuffleParam)
+		var shuffled struct {
+			Y     []types2.Point `json:"y"`
+			Index []int          `json:"index"`
+		}
+		if err := json.Unmarshal([]byte(client.Shuffle(string(sstr))), &shuffled); err != nil {
+			return err
+		}
+		req.Y = shuffled.Y
+		req.Index = shuffled.Index
+	}
+
+	cli := NewHttpClient(MainNet)
+	length, err := CallEpochLength(cli)
+	if err != nil {
+		return err
+	}
+	user := &HCashUser{Epoch: length}
+	req.Epoch = int(user.getEpoch())
+	if req.Accounts, err = CallSimulateAccounts(cli, req.Y, int64(req.Epoch)); err != nil {
+		return err
+	}
+
+	param, _ := json.Marshal(req)
+	result := client.NewProofRequest(string(param))
+	if result == "" {
+		return errors.New("build proof request failed")
+	}
+	log.Printf("proof request for epoch %d, it must be proven and sent within the epoch\n", req.Epoch)
+	return writeOutput(*out, result)
+}
+
+// proveCmd answers a proof request with the Zether key, it does not touch
+// the network.
+func proveCmd(args []string) error {
+	fs := flag.NewFlagSet("prove", flag.ExitOnError)
+	file := fs.String("f", "", "proof request file")
+	keystore := fs.String("keystore", "", "keystore file of the proving account")
+	password := fs.String("password", "", "file holding the keystore password")
+	out := fs.String("o", "", "output file, stdout when empty")
+	fs.Parse(args)
+
+	data, err := ioutil.ReadFile(*file)
+	if err != nil {
+		return err
+	}
+	var param client.ProveRequestParam
+	if err := json.Unmarshal(data, &param.Request); err != nil {
+		return err
+	}
+	if param.Accounter, err = loadKeystore(*keystore, *password); err != nil {
+		return fmt.Errorf("-keystore: %w", err)
+	}
+	input, _ := json.Marshal(param)
+	result := client.ProveRequest(string(input))
+	if result == "" {
+		return errors.New("prove request failed")
+	}
+	return writeOutput(*out, result)
+}
+
+// txCmd checks the response against the request and prints the calldata,
+// with -sk it also signs and sends the transaction.
+func txCmd(args []string) error {
+	fs := flag.NewFlagSet("tx", flag.ExitOnError)
+	reqFile := fs.String("request", "", "proof request file")
+	resFile := fs.String("response", "", "proof response file")
+	senderKey := fs.String("sk", "", "sender private key in hex, the calldata is only printed without it")
+	fs.Parse(args)
+
+	var param client.ProofResponseTxParam
+	var files = []struct {
+		path string
+		v    interface{}
+	}{{*reqFile, &param.Request}, {*resFile, &param.Response}}
+	for _, f := range files {
+		data, err := ioutil.ReadFile(f.path)
+		if err != nil {
+			return err
+		}
+		if err := json.Unmarshal(data, f.v); err != nil {
+			return fmt.Errorf("%s: %w", f.path, err)
+		}
+	}
+	input, _ := json.Marshal(param)
+	var tx client.ProofResponseTxResult
+	if err := json.Unmarshal([]byte(client.ProofResponseTx(string(input))), &tx); err != nil {
+		return errors.New("the proof response does not match the request")
+	}
+	if *senderKey == "" {
+		fmt.Printf("to: %s\ndata: %s\n", tx.To, tx.Data)
+		return nil
+	}
+
+	priv, err := crypto.HexToECDSA(strings.TrimPrefix(*senderKey, "0x"))
+	if err != nil {
+		return errors.New("invalid send privatekey")
+	}
+	SenderAddr = getAddrFromPrivkey(priv)
+	if param.Request.Kind == client.ProofKindBurn && !strings.EqualFold(SenderAddr.Hex(), param.Request.Sender) {
+		return fmt.Errorf("burn proof is bound to sender %s", param.Request.Sender)
+	}
+	return sendTx(NewHttpClient(MainNet), param.Request.Kind, priv, tx.Data)
+}
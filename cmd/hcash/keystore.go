@@ -0,0 +1,143 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"math/big"
+	"os"
+	"strings"
+
+	common2 "github.com/hpb-project/HCash-SDK/common"
+	"github.com/hpb-project/HCash-SDK/core"
+	"github.com/hpb-project/HCash-SDK/core/ebigint"
+)
+
+// Zether accounts are kept in keystore files:
+//
+//	hcash keystore new    -o alice.json
+//	hcash keystore import -o alice.json -x <file>
+//	hcash keystore export -f alice.json
+//	hcash keystore passwd -f alice.json
+//
+// The password is read from -password <file>, then $HCASH_PASSWORD, then
+// the terminal. The terminal does not hide it. The x to import is read from
+// -x <file>, else the terminal, never from the command line.
+const passwordEnv = "HCASH_PASSWORD"
+
+func keystoreCmd(args []string) error {
+	if len(args) == 0 {
+		return errors.New("usage: hcash keystore new|import|export|passwd [flags]")
+	}
+	fs := flag.NewFlagSet("keystore "+args[0], flag.ExitOnError)
+	file := fs.String("f", "", "keystore file")
+	out := fs.String("o", "", "output keystore file, stdout when empty")
+	secret := fs.String("x", "", "file holding the zether private key in hex, import only")
+	password := fs.String("password", "", "file holding the password")
+	newPassword := fs.String("newpassword", "", "file holding the new password, passwd only")
+	kdf := fs.String("kdf", core.KDFScrypt, "scrypt or argon2id")
+	light := fs.Bool("light", false, "cheaper kdf parameters")
+	fs.Parse(args[1:])
+	var opts = core.KeystoreOptions{KDF: *kdf, Light: *light}
+
+	var ks *core.Keystore
+	switch args[0] {
+	case "new", "import":
+		var account core.Account
+		if args[0] == "new" {
+			account = core.CreateAccount()
+		} else {
+			line, err := readLine(*secret, "", "Private key: ")
+			if err != nil {
+				return err
+			}
+			b128 := core.NewBN128()
+			x, ok := new(big.Int).SetString(common2.HexWithout0x(strings.TrimSpace(line)), 16)
+			if !ok || x.Sign() <= 0 || x.Cmp(b128.Q().Int) >= 0 {
+				return errors.New("-x: invalid private key")
+			}
+			account = core.CreateAccountWithX(ebigint.ToNBigInt(x).ForceRed(b128.Q()))
+		}
+		pass, err := readPassword(*password, "Password: ")
+		if err != nil {
+			return err
+		}
+		if ks, err = core.EncryptKey(account, pass, opts); err != nil {
+			return err
+		}
+	case "export":
+		account, err := loadKeystore(*file, *password)
+		if err != nil {
+			return err
+		}
+		fmt.Println(account.String())
+		return nil
+	case "passwd":
+		old, err := core.LoadKeystoreFile(*file)
+		if err != nil {
+			return err
+		}
+		pass, err := readPassword(*password, "Password: ")
+		if err != nil {
+			return err
+		}
+		newPass, err := readLine(*newPassword, "", "New password: ")
+		if err != nil {
+			return err
+		}
+		if ks, err = old.ChangePassword(pass, newPass, opts); err != nil {
+			return err
+		}
+		if *out == "" {
+			*out = *file
+		}
+	default:
+		return fmt.Errorf("unknown keystore command %s", args[0])
+	}
+	if *out == "" {
+		data, _ := json.Marshal(ks)
+		fmt.Println(string(data))
+		return nil
+	}
+	return ks.SaveFile(*out)
+}
+
+// loadKeystore decrypts the keystore file at path.
+func loadKeystore(path string, passwordFile string) (core.Account, error) {
+	ks, err := core.LoadKeystoreFile(path)
+	if err != nil {
+		return core.Account{}, err
+	}
+	pass, err := readPassword(passwordFile, fmt.Sprintf("Password of %s: ", path))
+	if err != nil {
+		return core.Account{}, err
+	}
+	return ks.Decrypt(pass)
+}
+
+func readPassword(file string, prompt string) (string, error) {
+	return readLine(file, passwordEnv, prompt)
+}
+
+// readLine reads the first line of file, else env, else a line from stdin.
+func readLine(file string, env string, prompt string) (string, error) {
+	if file != "" {
+		data, err := ioutil.ReadFile(file)
+		if err != nil {
+			return "", err
+		}
+		return strings.TrimRight(strings.SplitN(string(data), "\n", 2)[0], "\r"), nil
+	}
+	if v, ok := os.LookupEnv(env); ok && env != "" {
+		return v, nil
+	}
+	fmt.Fprint(os.Stderr, prompt)
+	line, err := bufio.NewReader(os.Stdin).ReadString('\n')
+	if err != nil && line == "" {
+		return "", err
+	}
+	return strings.TrimRight(line, "\r\n"), nil
+}
@@ -19,6 +19,7 @@ import (
 	"github.com/hpb-project/HCash-SDK/core/client"
 	"log"
 	"math/big"
+	"os"
 	"strings"
 	"time"
 )
@@ -130,13 +131,21 @@ type HCashUser struct {
 	Friends map[string]types2.Point
 }
 
-func (h *HCashUser) addFriend(name string, xy []string) {
+// addFriend adds the account of an address, an address bound to another
+// ZSC contract is refused.
+func (h *HCashUser) addFriend(name string, address string) error {
 	if _, exist := h.Friends[name]; exist {
-		return
+		return nil
+	}
+	a, err := types2.DecodeAddress(address)
+	if err != nil {
+		return err
+	}
+	if a.Contract != nil && common.BytesToAddress(a.Contract) != ZSCContract {
+		return fmt.Errorf("address of %s is for contract %s", name, common.BytesToAddress(a.Contract).Hex())
 	}
-	var y types2.Point
-	y.Set(xy)
-	h.Friends[name] = y
+	h.Friends[name] = a.Y
+	return nil
 }
 
 func (h *HCashUser) getEpoch() int64 {
@@ -296,11 +305,34 @@ func (h *HCashUser) burn(cli *HttpClient, value int, priv *ecdsa.PrivateKey) err
 }
 
 func main() {
+	if len(os.Args) > 1 && os.Args[1] == "verify" {
+		if err := verifyCmd(os.Args[2:]); err != nil {
+			log.Printf("verify failed, err = %v\n", err)
+			os.Exit(1)
+		}
+		return
+	}
+	if len(os.Args) > 1 && os.Args[1] == "keystore" {
+		if err := keystoreCmd(os.Args[2:]); err != nil {
+			log.Printf("keystore failed, err = %v\n", err)
+			os.Exit(1)
+		}
+		return
+	}
+	if len(os.Args) > 1 && (os.Args[1] == "request" || os.Args[1] == "prove" || os.Args[1] == "tx") {
+		if err := airgapCmd(os.Args[1], os.Args[2:]); err != nil {
+			log.Printf("%s failed, err = %v\n", os.Args[1], err)
+			os.Exit(1)
+		}
+		return
+	}
 
 	senderPrivKey := flag.String("sk", "", "Sender private key in hex")
-	alicePrivKey := flag.String("ak", "", "alice private key in hex")
+	aliceKeystore := flag.String("keystore", "", "alice keystore file")
+	passwordFile := flag.String("password", "", "file holding the keystore password")
 	doBurn := flag.Bool("b", false, "do burn if balance > 0")
 	doTx := flag.Bool("t", false, "do transfer if balance > 0")
+	bobAddress := flag.String("to", "hc1qg5d2hdcgddglhvnhu8yp5ee6tcumznsx0h50ctr2626jjkanwz62sk2x9k", "address of bob, the receiver of -t")
 
 	flag.Parse()
 
@@ -317,16 +349,24 @@ func main() {
 	SenderAddr = getAddrFromPrivkey(senderPriv)
 
 	cli := NewHttpClient(MainNet)
-	alice, err := RecoverUser(*alicePrivKey)
+	account, err := loadKeystore(*aliceKeystore, *passwordFile)
+	if err != nil {
+		log.Printf("load keystore failed, err = %v\n", err)
+		return
+	}
+	alice, err := RecoverUser(account.X.String())
 	if err != nil {
 		log.Printf("recover user failed, err = %v\n", err)
 		return
 	}
 
-	alice.addFriend("bob", []string{
-		"0x28d55db8435a8fdd93bf0e40d339d2f1cd8a7033ef47e1635695a94add9b85a5",
-		"0x23f58460eda5eb93a8995649ef25d51221aa206b6242080322eea2cd910019d2",
-	})
+	if err := alice.addFriend("bob", *bobAddress); err != nil {
+		log.Printf("invalid address of bob, err = %v\n", err)
+		return
+	}
+	if address, err := alice.Y.Address(types2.MainnetPrefix); err == nil {
+		log.Printf("alice address %s\n", address)
+	}
 
 	alice.Epoch, err = CallEpochLength(cli)
 	if err != nil {
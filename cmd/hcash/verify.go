@@ -0,0 +1,77 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"log"
+
+	types2 "github.com/hpb-project/HCash-SDK/common/types"
+	"github.com/hpb-project/HCash-SDK/core/client"
+)
+
+// verifyCmd checks a transfer or burn proof locally, without submitting
+// anything on chain. The input file holds a client.VerifyTransferParam or
+// client.VerifyBurnParam; when its accounts are left empty, they are
+// fetched from the ZSC contract with simulateAccounts at the given epoch.
+//
+//	hcash verify -kind transfer -f proof.json
+func verifyCmd(args []string) error {
+	fs := flag.NewFlagSet("verify", flag.ExitOnError)
+	kind := fs.String("kind", "transfer", "proof kind, transfer or burn")
+	file := fs.String("f", "", "json file with the statement and proof")
+	fs.Parse(args)
+
+	if *file == "" {
+		return errors.New("missing -f")
+	}
+	data, err := ioutil.ReadFile(*file)
+	if err != nil {
+		return err
+	}
+
+	var result string
+	switch *kind {
+	case "transfer":
+		var param client.VerifyTransferParam
+		if err := json.Unmarshal(data, &param); err != nil {
+			return err
+		}
+		if len(param.Accounts) == 0 {
+			sims, err := CallSimulateAccounts(NewHttpClient(MainNet), param.Y, int64(param.Epoch))
+			if err != nil {
+				return err
+			}
+			param.Accounts = sims
+		}
+		str, _ := json.Marshal(param)
+		result = client.VerifyTransfer(string(str))
+	case "burn":
+		var param client.VerifyBurnParam
+		if err := json.Unmarshal(data, &param); err != nil {
+			return err
+		}
+		if len(param.Accounts) == 0 {
+			sims, err := CallSimulateAccounts(NewHttpClient(MainNet), []types2.Point{param.Y}, int64(param.Epoch))
+			if err != nil {
+				return err
+			}
+			param.Accounts = sims[0][:]
+		}
+		str, _ := json.Marshal(param)
+		result = client.VerifyBurn(string(str))
+	default:
+		return fmt.Errorf("unknown proof kind %s", *kind)
+	}
+	var res client.VerifyResponse
+	if err := json.Unmarshal([]byte(result), &res); err != nil {
+		return errors.New("verify failed")
+	}
+	log.Printf("verify %s: %s\n", *kind, result)
+	if !res.Valid {
+		return errors.New(res.Reason)
+	}
+	return nil
+}
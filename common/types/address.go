@@ -0,0 +1,209 @@
+package types
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/hpb-project/HCash-SDK/core/bn256"
+)
+
+// An address is the text form of a public key for people to copy, e.g.
+//
+//	hc1q...
+//
+// It is bech32m (BIP350): a prefix naming the network, the separator 1,
+// the data in a 32 letter alphabet and a 6 letter checksum that catches
+// any 4 wrong letters. The data is the compressed y, optionally followed
+// by the 20 bytes of the ZSC contract the key is registered in. Addresses
+// are lower case, upper case is accepted, mixed case is not.
+const (
+	MainnetPrefix = "hc"
+	TestnetPrefix = "thc"
+
+	addressMaxLength = 120
+	bech32mConst     = 0x2bc830a3
+	bech32Charset    = "qpzry9x8gf2tvdw0s3jn54khce6mua7l"
+)
+
+var ErrInvalidAddress = errors.New("invalid address")
+
+// Address is a decoded address.
+type Address struct {
+	Prefix string
+	Y      Point
+	// Contract is the ZSC address, nil for an address of any contract.
+	Contract []byte
+}
+
+// NewAddress returns the address of y for any contract.
+func NewAddress(prefix string, y Point) Address {
+	return Address{Prefix: prefix, Y: y}
+}
+
+// EncodeAddress encodes a, y must not be the identity.
+func EncodeAddress(a Address) (string, error) {
+	if err := checkPrefix(a.Prefix); err != nil {
+		return "", err
+	}
+	if a.Y.IsIdentity() {
+		return "", fmt.Errorf("%w: y is the identity", ErrInvalidAddress)
+	}
+	if len(a.Contract) != 0 && len(a.Contract) != 20 {
+		return "", fmt.Errorf("%w: contract of %d bytes", ErrInvalidAddress, len(a.Contract))
+	}
+	y := a.Y.Compress()
+	data := convertBits(append(y[:], a.Contract...), 8, 5, true)
+	var b strings.Builder
+	b.WriteString(a.Prefix)
+	b.WriteByte('1')
+	for _, d := range append(data, bech32Checksum(a.Prefix, data)...) {
+		b.WriteByte(bech32Charset[d])
+	}
+	return b.String(), nil
+}
+
+// DecodeAddress decodes and checks an address of any prefix, the caller
+// checks that Prefix and Contract are the expected ones.
+func DecodeAddress(s string) (Address, error) {
+	if len(s) > addressMaxLength {
+		return Address{}, fmt.Errorf("%w: %d characters", ErrInvalidAddress, len(s))
+	}
+	lower := strings.ToLower(s)
+	if lower != s && strings.ToUpper(s) != s {
+		return Address{}, fmt.Errorf("%w: mixed case", ErrInvalidAddress)
+	}
+	sep := strings.LastIndexByte(lower, '1')
+	if sep < 1 || sep+7 > len(lower) {
+		return Address{}, fmt.Errorf("%w: no separator", ErrInvalidAddress)
+	}
+	prefix := lower[:sep]
+	if err := checkPrefix(prefix); err != nil {
+		return Address{}, err
+	}
+	var data = make([]byte, len(lower)-sep-1)
+	for i := range data {
+		d := strings.IndexByte(bech32Charset, lower[sep+1+i])
+		if d < 0 {
+			return Address{}, fmt.Errorf("%w: character %q", ErrInvalidAddress, lower[sep+1+i])
+		}
+		data[i] = byte(d)
+	}
+	if bech32Polymod(append(bech32ExpandPrefix(prefix), data...)) != bech32mConst {
+		return Address{}, fmt.Errorf("%w: checksum", ErrInvalidAddress)
+	}
+	payload := convertBits(data[:len(data)-6], 5, 8, false)
+	if payload == nil || (len(payload) != bn256.CompressedG1Size && len(payload) != bn256.CompressedG1Size+20) {
+		return Address{}, fmt.Errorf("%w: data length", ErrInvalidAddress)
+	}
+	c, err := CompressedPointFromBytes(payload[:bn256.CompressedG1Size])
+	if err != nil {
+		return Address{}, fmt.Errorf("%w: %s", ErrInvalidAddress, err.Error())
+	}
+	var a = Address{Prefix: prefix}
+	if a.Y, err = c.Decompress(); err != nil || a.Y.IsIdentity() {
+		return Address{}, fmt.Errorf("%w: y", ErrInvalidAddress)
+	}
+	if len(payload) > bn256.CompressedG1Size {
+		a.Contract = payload[bn256.CompressedG1Size:]
+	}
+	return a, nil
+}
+
+// Address encodes p for any contract.
+func (p Point) Address(prefix string) (string, error) {
+	return EncodeAddress(NewAddress(prefix, p))
+}
+
+// String is the encoded address, empty for an invalid one.
+func (a Address) String() string {
+	s, _ := EncodeAddress(a)
+	return s
+}
+
+func (a Address) MarshalText() ([]byte, error) {
+	s, err := EncodeAddress(a)
+	return []byte(s), err
+}
+
+func (a *Address) UnmarshalText(input []byte) error {
+	na, err := DecodeAddress(string(input))
+	if err != nil {
+		return err
+	}
+	*a = na
+	return nil
+}
+
+func checkPrefix(prefix string) error {
+	if len(prefix) == 0 || len(prefix) > 20 {
+		return fmt.Errorf("%w: prefix %q", ErrInvalidAddress, prefix)
+	}
+	for _, c := range prefix {
+		if c < 'a' || c > 'z' {
+			return fmt.Errorf("%w: prefix %q", ErrInvalidAddress, prefix)
+		}
+	}
+	return nil
+}
+
+func bech32Polymod(values []byte) uint32 {
+	var gen = [5]uint32{0x3b6a57b2, 0x26508e6d, 0x1ea119fa, 0x3d4233dd, 0x2a1462b3}
+	var chk uint32 = 1
+	for _, v := range values {
+		top := chk >> 25
+		chk = (chk&0x1ffffff)<<5 ^ uint32(v)
+		for i := 0; i < 5; i++ {
+			if (top>>uint(i))&1 == 1 {
+				chk ^= gen[i]
+			}
+		}
+	}
+	return chk
+}
+
+func bech32ExpandPrefix(prefix string) []byte {
+	var result = make([]byte, 0, 2*len(prefix)+1)
+	for i := 0; i < len(prefix); i++ {
+		result = append(result, prefix[i]>>5)
+	}
+	result = append(result, 0)
+	for i := 0; i < len(prefix); i++ {
+		result = append(result, prefix[i]&31)
+	}
+	return result
+}
+
+func bech32Checksum(prefix string, data []byte) []byte {
+	values := append(bech32ExpandPrefix(prefix), data...)
+	polymod := bech32Polymod(append(values, 0, 0, 0, 0, 0, 0)) ^ bech32mConst
+	var checksum = make([]byte, 6)
+	for i := range checksum {
+		checksum[i] = byte(polymod>>uint(5*(5-i))) & 31
+	}
+	return checksum
+}
+
+// convertBits regroups bits from groups of from to groups of to, nil when
+// the padding of a decoding is not zero.
+func convertBits(data []byte, from, to uint, pad bool) []byte {
+	var acc, bits uint
+	var result []byte
+	maxv := uint(1)<<to - 1
+	for _, v := range data {
+		acc = acc<<from | uint(v)
+		bits += from
+		for bits >= to {
+			bits -= to
+			result = append(result, byte(acc>>bits&maxv))
+		}
+	}
+	if pad {
+		if bits > 0 {
+			result = append(result, byte(acc<<(to-bits)&maxv))
+		}
+	} else if bits >= from || acc<<(to-bits)&maxv != 0 {
+		return nil
+	}
+	return result
+}
@@ -0,0 +1,101 @@
+package types
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"gotest.tools/assert"
+)
+
+func TestBech32m(t *testing.T) {
+	// valid checksums of BIP350.
+	for _, s := range []string{
+		"a1lqfn3a",
+		"abcdef1l7aum6echk45nj3s0wdvt2fg8x9yrzpqzd3ryx",
+		"split1checkupstagehandshakeupstreamerranterredcaperredlc445v",
+	} {
+		sep := strings.LastIndexByte(s, '1')
+		var data []byte
+		for _, c := range s[sep+1:] {
+			data = append(data, byte(strings.IndexRune(bech32Charset, c)))
+		}
+		assert.Equal(t, bech32Polymod(append(bech32ExpandPrefix(s[:sep]), data...)), uint32(bech32mConst), s)
+		assert.DeepEqual(t, bech32Checksum(s[:sep], data[:len(data)-6]), data[len(data)-6:])
+	}
+}
+
+func TestAddress(t *testing.T) {
+	p := MustPoint(gx, gy)
+	s, err := p.Address(MainnetPrefix)
+	assert.NilError(t, err)
+	assert.Assert(t, strings.HasPrefix(s, "hc1"), s)
+	a, err := DecodeAddress(s)
+	assert.NilError(t, err)
+	assert.Equal(t, a.Prefix, MainnetPrefix)
+	assert.Assert(t, a.Y.Equal(p))
+	assert.Assert(t, a.Contract == nil)
+
+	upper, err := DecodeAddress(strings.ToUpper(s))
+	assert.NilError(t, err)
+	assert.Assert(t, upper.Y.Equal(p))
+
+	contract, _ := hex.DecodeString("e4920905e06c6b6070477c40b85756ffda3cd3e6")
+	bound := Address{Prefix: TestnetPrefix, Y: p, Contract: contract}
+	data, err := json.Marshal(bound)
+	assert.NilError(t, err)
+	var fromJSON Address
+	assert.NilError(t, json.Unmarshal(data, &fromJSON))
+	assert.DeepEqual(t, fromJSON, bound)
+
+	// a Point takes a plain mainnet address wherever it takes its text form,
+	// the others have to be checked through DecodeAddress.
+	var params struct {
+		Y []Point `json:"y"`
+	}
+	assert.NilError(t, json.Unmarshal([]byte(`{"y":["`+s+`"]}`), &params))
+	assert.Assert(t, params.Y[0].Equal(p))
+	for _, other := range []Address{
+		bound,
+		{Prefix: TestnetPrefix, Y: p},
+		{Prefix: MainnetPrefix, Y: p, Contract: contract},
+	} {
+		err := json.Unmarshal([]byte(`{"y":["`+other.String()+`"]}`), &params)
+		assert.Assert(t, errors.Is(err, ErrInvalidAddress), other.String())
+	}
+
+	// every single wrong letter is caught.
+	for i := len("hc1"); i < len(s); i++ {
+		for _, c := range bech32Charset {
+			if byte(c) == s[i] {
+				continue
+			}
+			_, err := DecodeAddress(s[:i] + string(c) + s[i+1:])
+			assert.Assert(t, errors.Is(err, ErrInvalidAddress), s[:i]+string(c)+s[i+1:])
+		}
+	}
+
+	var invalid = []string{
+		s[:len(s)-1],
+		"Hc1" + s[3:],
+		"thc" + s[2:],
+		s[:3] + "b" + s[4:],
+		"hc" + s[3:],
+		"",
+	}
+	for _, c := range invalid {
+		_, err := DecodeAddress(c)
+		assert.Assert(t, errors.Is(err, ErrInvalidAddress), c)
+		var bad Point
+		assert.Assert(t, json.Unmarshal([]byte(`"`+c+`"`), &bad) != nil, c)
+	}
+
+	_, err = Point{}.Address(MainnetPrefix)
+	assert.Assert(t, errors.Is(err, ErrInvalidAddress))
+	_, err = EncodeAddress(Address{Prefix: "HC", Y: p})
+	assert.Assert(t, errors.Is(err, ErrInvalidAddress))
+	_, err = EncodeAddress(Address{Prefix: MainnetPrefix, Y: p, Contract: contract[:19]})
+	assert.Assert(t, errors.Is(err, ErrInvalidAddress))
+}
@@ -0,0 +1,78 @@
+package types
+
+import (
+	"encoding/hex"
+	"fmt"
+
+	"github.com/hpb-project/HCash-SDK/common"
+	"github.com/hpb-project/HCash-SDK/core/bn256"
+)
+
+// CompressedPoint is the 33 bytes form of a Point: 0x02 or 0x03 for the
+// parity of y, then x. The identity is all zeros. The contracts only take
+// Point, use Decompress before building calldata.
+type CompressedPoint [bn256.CompressedG1Size]byte
+
+// Compress returns the compressed form of p.
+func (p Point) Compress() CompressedPoint {
+	var c CompressedPoint
+	if p.IsIdentity() {
+		return c
+	}
+	g := new(bn256.G1)
+	if _, err := g.Unmarshal(p[:]); err != nil {
+		// only reachable for a Point filled in by hand.
+		panic(err)
+	}
+	copy(c[:], g.MarshalCompressed())
+	return c
+}
+
+// CompressedPointFromBytes decodes the 33 bytes compressed encoding.
+func CompressedPointFromBytes(b []byte) (CompressedPoint, error) {
+	var c CompressedPoint
+	if len(b) != len(c) {
+		return CompressedPoint{}, fmt.Errorf("%w: length %d", ErrInvalidPoint, len(b))
+	}
+	copy(c[:], b)
+	if _, err := c.Decompress(); err != nil {
+		return CompressedPoint{}, err
+	}
+	return c, nil
+}
+
+// Decompress recovers y and returns the point in the contract layout.
+func (c CompressedPoint) Decompress() (Point, error) {
+	g := new(bn256.G1)
+	if _, err := g.UnmarshalCompressed(c[:]); err != nil {
+		return Point{}, fmt.Errorf("%w: %s", ErrInvalidPoint, err.Error())
+	}
+	var p Point
+	copy(p[:], g.Marshal())
+	return p, nil
+}
+
+func (c CompressedPoint) Bytes() []byte {
+	return append([]byte{}, c[:]...)
+}
+
+func (c CompressedPoint) MarshalText() ([]byte, error) {
+	return []byte("0x" + hex.EncodeToString(c[:])), nil
+}
+
+func (c *CompressedPoint) UnmarshalText(input []byte) error {
+	d, err := hex.DecodeString(common.HexWithout0x(string(input)))
+	if err != nil {
+		return fmt.Errorf("%w: %s", ErrInvalidPoint, err.Error())
+	}
+	nc, err := CompressedPointFromBytes(d)
+	if err != nil {
+		return err
+	}
+	*c = nc
+	return nil
+}
+
+func (c CompressedPoint) String() string {
+	return "0x" + hex.EncodeToString(c[:])
+}
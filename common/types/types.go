@@ -1,78 +1,189 @@
 package types
 
 import (
-	"bytes"
+	"encoding/hex"
 	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
 	"github.com/hpb-project/HCash-SDK/common"
+	"github.com/hpb-project/HCash-SDK/core/bn256"
 )
 
-type Point [2]string
+var ErrInvalidPoint = errors.New("invalid point")
+
+// Point is a BN128 G1 point in the layout of the contracts, x then y as
+// 32 bytes big-endian. The zero value (0, 0) is the identity.
+//
+// Every decoder of Point checks that the coordinates are below the field
+// modulus and that the point is on the curve, so a Point that was decoded
+// or built by NewPoint is always valid.
+type Point [64]byte
+
+// NewPoint parses the hex coordinates x and y, with or without 0x.
+func NewPoint(x, y string) (Point, error) {
+	var p Point
+	for i, c := range []string{x, y} {
+		c = common.HexWithout0x(c)
+		if c == "" || len(c) > 64 {
+			return Point{}, fmt.Errorf("%w: bad coordinate %q", ErrInvalidPoint, c)
+		}
+		if len(c)%2 == 1 {
+			c = "0" + c
+		}
+		d, err := hex.DecodeString(c)
+		if err != nil {
+			return Point{}, fmt.Errorf("%w: bad coordinate %q", ErrInvalidPoint, c)
+		}
+		copy(p[32*i+32-len(d):32*i+32], d)
+	}
+	if err := p.Validate(); err != nil {
+		return Point{}, err
+	}
+	return p, nil
+}
+
+// MustPoint is NewPoint for constants, it panics on invalid input.
+func MustPoint(x, y string) Point {
+	p, err := NewPoint(x, y)
+	if err != nil {
+		panic(err)
+	}
+	return p
+}
+
+// PointFromBytes decodes the 64 bytes x||y encoding.
+func PointFromBytes(b []byte) (Point, error) {
+	var p Point
+	if len(b) != len(p) {
+		return Point{}, fmt.Errorf("%w: length %d", ErrInvalidPoint, len(b))
+	}
+	copy(p[:], b)
+	if err := p.Validate(); err != nil {
+		return Point{}, err
+	}
+	return p, nil
+}
+
+// Validate checks that the coordinates are canonical and the point is the
+// identity or on the curve.
+func (p Point) Validate() error {
+	if p.IsIdentity() {
+		return nil
+	}
+	if _, err := new(bn256.G1).Unmarshal(p[:]); err != nil {
+		return fmt.Errorf("%w: %s", ErrInvalidPoint, err.Error())
+	}
+	return nil
+}
+
+func (p Point) IsIdentity() bool {
+	return p == Point{}
+}
 
 func (p Point) GX() string {
-	return p[0]
+	return "0x" + hex.EncodeToString(p[:32])
 }
 
 func (p Point) GY() string {
-	return p[1]
+	return "0x" + hex.EncodeToString(p[32:])
 }
 
-func (p *Point) Set(xy []string) *Point {
-	p[0] = xy[0]
-	p[1] = xy[1]
-	return p
+func (p Point) Bytes() []byte {
+	return append([]byte{}, p[:]...)
 }
 
 func (p Point) Equal(o Point) bool {
-	return p[0] == o[0] && p[1] == o[1]
+	return p == o
+}
+
+// Match is Equal, kept for the callers that compared the hex strings.
+func (p Point) Match(o Point) bool {
+	return p == o
+}
+
+func (p Point) XY() string {
+	return "0x" + hex.EncodeToString(p[:])
+}
+
+type jsonPoint struct {
+	GX string `json:"gx"`
+	GY string `json:"gy"`
 }
 
 func (p Point) MarshalJSON() ([]byte, error) {
-	type IPubkey struct {
-		GX string `json:"gx"`
-		GY string `json:"gy"`
-	}
-	var enc IPubkey
-	enc.GX = p[0]
-	enc.GY = p[1]
-	return json.Marshal(enc)
+	return json.Marshal(jsonPoint{GX: p.GX(), GY: p.GY()})
 }
 
+// UnmarshalJSON accepts {"gx":"", "gy":""} as well as the text form.
 func (p *Point) UnmarshalJSON(input []byte) error {
-	type IPubkey struct {
-		GX string `json:"gx"`
-		GY string `json:"gy"`
+	if len(input) > 0 && input[0] == '"' {
+		var text string
+		if err := json.Unmarshal(input, &text); err != nil {
+			return err
+		}
+		return p.UnmarshalText([]byte(text))
 	}
-	var enc IPubkey
+	var enc jsonPoint
 	if err := json.Unmarshal(input, &enc); err != nil {
 		return err
 	}
-	p[0] = enc.GX
-	p[1] = enc.GY
+	np, err := NewPoint(enc.GX, enc.GY)
+	if err != nil {
+		return err
+	}
+	*p = np
 	return nil
 }
 
-func (p Point) String() string {
-	d, _ := json.Marshal(p)
-	return string(d)
+// MarshalText encodes the point as 0x followed by the 128 hex digits of
+// x||y, the layout of the contract calldata.
+func (p Point) MarshalText() ([]byte, error) {
+	return []byte(p.XY()), nil
 }
 
-func (p Point) XY() string {
-	if len(p[0]) > 2 && len(p[1]) > 2 {
-		return "0x" + p[0][2:] + p[1][2:]
+// UnmarshalText accepts the hex of MarshalText as well as a mainnet address
+// without a contract. Testnet and contract bound addresses only go through
+// DecodeAddress, where the caller checks the prefix and contract.
+func (p *Point) UnmarshalText(input []byte) error {
+	var text = common.HexWithout0x(string(input))
+	if len(text) != 128 && strings.Contains(text, "1") && !strings.HasPrefix(string(input), "0x") {
+		a, err := DecodeAddress(string(input))
+		if err != nil {
+			return err
+		}
+		if a.Prefix != MainnetPrefix || a.Contract != nil {
+			return fmt.Errorf("%w: %s address bound to a network or contract, decode it with DecodeAddress", ErrInvalidAddress, a.Prefix)
+		}
+		*p = a.Y
+		return nil
+	}
+	if len(text) != 128 {
+		return fmt.Errorf("%w: want 128 hex digits, got %d", ErrInvalidPoint, len(text))
 	}
-	return "0x"
+	np, err := NewPoint(text[:64], text[64:])
+	if err != nil {
+		return err
+	}
+	*p = np
+	return nil
 }
 
-func (p Point) Match(o Point) bool {
-	px := common.FromHex(p.GX())
-	py := common.FromHex(p.GY())
-
-	ox := common.FromHex(o.GX())
-	oy := common.FromHex(o.GY())
+func (p Point) MarshalBinary() ([]byte, error) {
+	return p.Bytes(), nil
+}
 
-	if bytes.Compare(px, ox) == 0 && bytes.Compare(py, oy) == 0 {
-		return true
-	} else {
-		return false
+func (p *Point) UnmarshalBinary(data []byte) error {
+	np, err := PointFromBytes(data)
+	if err != nil {
+		return err
 	}
+	*p = np
+	return nil
+}
+
+func (p Point) String() string {
+	d, _ := json.Marshal(p)
+	return string(d)
 }
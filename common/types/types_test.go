@@ -0,0 +1,94 @@
+package types
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"gotest.tools/assert"
+)
+
+const (
+	gx = "0x077da99d806abd13c9f15ece5398525119d11e11e9836b2ee7d23f6159ad87d4"
+	gy = "0x01485efa927f2ad41bff567eec88f32fb0a0f706588b4e41a8d587d008b7f875"
+)
+
+func TestNewPoint(t *testing.T) {
+	p, err := NewPoint(gx, gy)
+	assert.NilError(t, err)
+	assert.Equal(t, p.GX(), gx)
+	assert.Equal(t, p.GY(), gy)
+
+	q, err := NewPoint(gx[2:], "1485efa927f2ad41bff567eec88f32fb0a0f706588b4e41a8d587d008b7f875")
+	assert.NilError(t, err)
+	assert.Assert(t, p.Equal(q))
+
+	zero, err := NewPoint("0", "0")
+	assert.NilError(t, err)
+	assert.Assert(t, zero.IsIdentity())
+
+	var invalid = [][2]string{
+		{gx, "0x01485efa927f2ad41bff567eec88f32fb0a0f706588b4e41a8d587d008b7f876"},
+		{"0x30644e72e131a029b85045b68181585d97816a916871ca8d3c208c16d87cfd47", "0x2"},
+		{"0xzz", gy},
+		{"", gy},
+		{gx + "00", gy},
+	}
+	for _, c := range invalid {
+		_, err := NewPoint(c[0], c[1])
+		assert.Assert(t, errors.Is(err, ErrInvalidPoint), c)
+	}
+}
+
+func TestPointEncoding(t *testing.T) {
+	p := MustPoint(gx, gy)
+
+	data, err := json.Marshal(p)
+	assert.NilError(t, err)
+	assert.Equal(t, string(data), `{"gx":"`+gx+`","gy":"`+gy+`"}`)
+	var fromJSON Point
+	assert.NilError(t, json.Unmarshal(data, &fromJSON))
+	assert.Assert(t, fromJSON.Equal(p))
+
+	text, _ := p.MarshalText()
+	assert.Equal(t, string(text), gx+gy[2:])
+	var fromText Point
+	assert.NilError(t, json.Unmarshal([]byte(`"`+string(text)+`"`), &fromText))
+	assert.Assert(t, fromText.Equal(p))
+
+	bin, _ := p.MarshalBinary()
+	fromBin, err := PointFromBytes(bin)
+	assert.NilError(t, err)
+	assert.Assert(t, fromBin.Equal(p))
+
+	bin[63] ^= 1
+	_, err = PointFromBytes(bin)
+	assert.Assert(t, errors.Is(err, ErrInvalidPoint))
+	_, err = PointFromBytes(bin[:32])
+	assert.Assert(t, errors.Is(err, ErrInvalidPoint))
+
+	var bad Point
+	err = json.Unmarshal([]byte(`{"gx":"`+gx+`","gy":"0x1"}`), &bad)
+	assert.Assert(t, errors.Is(err, ErrInvalidPoint))
+	assert.Assert(t, bad.IsIdentity())
+}
+
+func TestCompressedPoint(t *testing.T) {
+	p := MustPoint(gx, gy)
+	c := p.Compress()
+	assert.Equal(t, c.String(), "0x03"+gx[2:])
+	d, err := c.Decompress()
+	assert.NilError(t, err)
+	assert.Assert(t, d.Equal(p))
+
+	var fromText CompressedPoint
+	text, _ := c.MarshalText()
+	assert.NilError(t, fromText.UnmarshalText(text))
+	assert.Equal(t, fromText, c)
+
+	assert.Equal(t, Point{}.Compress(), CompressedPoint{})
+	_, err = CompressedPointFromBytes(append([]byte{0x04}, c[1:]...))
+	assert.Assert(t, errors.Is(err, ErrInvalidPoint))
+	_, err = CompressedPointFromBytes(c[:32])
+	assert.Assert(t, errors.Is(err, ErrInvalidPoint))
+}
@@ -3,6 +3,7 @@ package core
 import (
 	"encoding/hex"
 	"fmt"
+	"io"
 	//	"log"
 	"reflect"
 
@@ -17,12 +18,14 @@ type GeneratorParams struct {
 	h  Point
 	gs *GeneratorVector
 	hs *GeneratorVector
+
+	fixedH *FixedBasePoint
 }
 
 func NewGeneratorParams(hi interface{}, gs, hs *GeneratorVector) *GeneratorParams {
 	gp := &GeneratorParams{}
-	hash := solsha3.SoliditySHA3(solsha3.String("G"))
-	gp.g = MapInto(hex.EncodeToString(hash))
+	// g is MapInto("G"), see CheckGeneratorTables.
+	gp.g = b128.CurveG()
 
 	h_types := reflect.TypeOf(hi).String()
 	if h_types == "int" {
@@ -40,6 +43,7 @@ func NewGeneratorParams(hi interface{}, gs, hs *GeneratorVector) *GeneratorParam
 			hsInnards = append(hsInnards, p2)
 		}
 		gp.h = MapInto(hex.EncodeToString(solsha3.SoliditySHA3(solsha3.String("H"))))
+		gp.fixedH = FixedH()
 
 		gp.gs = NewGeneratorVector(gsInnards)
 		gp.hs = NewGeneratorVector(hsInnards)
@@ -60,6 +64,20 @@ func (g GeneratorParams) GetH() Point {
 	return g.h
 }
 
+// GetFixedG returns g with its multiplication table.
+func (g GeneratorParams) GetFixedG() *FixedBasePoint {
+	return FixedG()
+}
+
+// GetFixedH returns h with a multiplication table when it is the shared
+// mapInto("H") generator.
+func (g GeneratorParams) GetFixedH() *FixedBasePoint {
+	if g.fixedH != nil {
+		return g.fixedH
+	}
+	return &FixedBasePoint{point: g.h}
+}
+
 func (g GeneratorParams) GetGS() *GeneratorVector {
 	return g.gs
 }
@@ -69,21 +87,19 @@ func (g GeneratorParams) GetHS() *GeneratorVector {
 }
 
 func (g *GeneratorParams) Commit(blinding *ebigint.NBigInt, gExp, hExp *FieldVector) Point {
-	var result = g.h.Mul(blinding)
-	var gsVector = g.gs.GetVector()
+	var points = []Point{g.h}
+	var scalars = []*ebigint.NBigInt{blinding}
+
 	gexpVector := gExp.GetVector()
-	for i, gexp := range gexpVector {
-		result = result.Add(gsVector[i].Mul(gexp))
-	}
+	points = append(points, g.gs.GetVector()[:len(gexpVector)]...)
+	scalars = append(scalars, gexpVector...)
 
 	if hExp != nil {
-		var hsVector = g.hs.GetVector()
 		hexpVector := hExp.GetVector()
-		for i, hexp := range hexpVector {
-			result = result.Add(hsVector[i].Mul(hexp))
-		}
+		points = append(points, g.hs.GetVector()[:len(hexpVector)]...)
+		scalars = append(scalars, hexpVector...)
 	}
-	return result
+	return MultiExp(points, scalars)
 }
 
 type FieldVector struct {
@@ -271,20 +287,8 @@ func (g *GeneratorVector) Slice(begin, end int) *GeneratorVector {
 }
 
 func (g *GeneratorVector) Commit(exponents *FieldVector) Point {
-	var nVectors = make([]Point, 0)
 	var innards = exponents.GetVector()
-
-	for _, c := range g.vector {
-		nVectors = append(nVectors, c)
-	}
-
-	var accumulator = b128.Zero()
-
-	for i, current := range nVectors {
-		accumulator = accumulator.Add(current.Mul(innards[i]))
-	}
-
-	return accumulator
+	return MultiExp(g.vector, innards[:len(g.vector)])
 }
 
 func (g *GeneratorVector) Sum() Point {
@@ -377,99 +381,6 @@ func (g *GeneratorVector) String() string {
 	return str
 }
 
-type Convolver struct {
-	unity *ebigint.NBigInt
-}
-
-func NewConvolver() *Convolver {
-	c := &Convolver{}
-
-	unity, _ := big.NewInt(0).SetString("14a3074b02521e3b1ed9852e5028452693e87be4e910500c7ba9bbddb2f46edd", 16)
-	c.unity = ebigint.ToNBigInt(unity).ToRed(b128.Q())
-
-	return c
-}
-
-func (c *Convolver) FFT_Scalar(input *FieldVector, inverse bool) *FieldVector {
-	var length = input.Length()
-	if length == 1 {
-		return input
-	}
-	if length%2 != 0 {
-		panic("Input size must be a power of 2!")
-	}
-
-	exp := big.NewInt(1).Lsh(big.NewInt(1), 28)
-	exp = exp.Div(exp, big.NewInt(int64(length)))
-
-	var omega = c.unity.RedExp(exp)
-	if inverse {
-		omega = omega.RedInvm()
-	}
-	var even = c.FFT_Scalar(input.Extract(0), inverse)
-	var odd = c.FFT_Scalar(input.Extract(1), inverse)
-
-	var omegas = make([]*ebigint.NBigInt, 0)
-	omegas = append(omegas, ebigint.NewNBigInt(1).ToRed(b128.Q()))
-
-	for i := 1; i < length/2; i++ {
-		omegas = append(omegas, omegas[i-1].RedMul(omega))
-	}
-
-	var n_omegas = NewFieldVector(omegas)
-	var result = even.Add(odd.Hadamard(n_omegas)).Concat(even.Add(odd.Hadamard(n_omegas).Negate()))
-	if inverse {
-		result = result.Times(ebigint.NewNBigInt(2).ToRed(b128.Q()).RedInvm())
-	}
-	return result
-}
-
-func (c *Convolver) Convolution_Scalar(exponent *FieldVector, base *FieldVector) *FieldVector {
-	size := base.Length()
-	temp := c.FFT_Scalar(base, false).Hadamard(c.FFT_Scalar(exponent.Flip(), false))
-
-	return c.FFT_Scalar(temp.Slice(0, size/2).Add(temp.Slice(size/2, size)).Times(ebigint.NewNBigInt(2).ToRed(b128.Q()).RedInvm()), true)
-}
-
-func (c *Convolver) Convolution_Point(exponent *FieldVector, base *GeneratorVector) *GeneratorVector {
-	size := base.Length()
-	temp := c.FFT_Point(base, false).Hadamard(c.FFT_Scalar(exponent.Flip(), false))
-
-	return c.FFT_Point(temp.Slice(0, size/2).Add(temp.Slice(size/2, size)).Times(ebigint.NewNBigInt(2).ToRed(b128.Q()).RedInvm()), true)
-}
-
-func (c *Convolver) FFT_Point(input *GeneratorVector, inverse bool) *GeneratorVector {
-	var length = input.Length()
-	if length == 1 {
-		return input
-	}
-	if length%2 != 0 {
-		panic("Input size must be a power of 2!")
-	}
-	exp := big.NewInt(1).Lsh(big.NewInt(1), 28)
-	exp = exp.Div(exp, big.NewInt(int64(length)))
-	var omega = c.unity.RedExp(exp)
-	if inverse {
-		omega = omega.RedInvm()
-	}
-	var even = c.FFT_Point(input.Extract(0), inverse)
-	var odd = c.FFT_Point(input.Extract(1), inverse)
-
-	var omegas = make([]*ebigint.NBigInt, 0)
-	omegas = append(omegas, ebigint.NewNBigInt(1).ToRed(b128.Q()))
-
-	for i := 1; i < length/2; i++ {
-		omegas = append(omegas, omegas[i-1].RedMul(omega))
-	}
-
-	var n_omegas = NewFieldVector(omegas)
-	var result = even.Add(odd.Hadamard(n_omegas)).Concat(even.Add(odd.Hadamard(n_omegas).Negate()))
-	if inverse {
-		result = result.Times(ebigint.NewNBigInt(2).ToRed(b128.Q()).RedInvm())
-	}
-	return result
-}
-
 type FieldVectorPolynomial struct {
 	//coefficients []*PedersenCommitment
 	coefficients []*FieldVector
@@ -555,19 +466,24 @@ type PolyCommitment struct {
 	coefficientCommitments []*PedersenCommitment
 }
 
-func NewPolyCommitment(params GeneratorParams, coefficients []*ebigint.NBigInt) *PolyCommitment {
+// NewPolyCommitment commits to the coefficients, blinding all but the first
+// with scalars read from random.
+func NewPolyCommitment(params GeneratorParams, coefficients []*ebigint.NBigInt, random io.Reader) (*PolyCommitment, error) {
 	pc := &PolyCommitment{}
 	pc.coefficientCommitments = make([]*PedersenCommitment, 0)
 	tmp := NewPedersenCommitment(params, coefficients[0], ebigint.NewNBigInt(0).ToRed(b128.Q()))
 	pc.coefficientCommitments = append(pc.coefficientCommitments, tmp)
 
 	for _, coefficient := range coefficients[1:] {
-		rand := b128.RandomScalar()
+		rand, err := RandomScalarFrom(random)
+		if err != nil {
+			return nil, err
+		}
 		npc := NewPedersenCommitment(params, coefficient, rand)
 		pc.coefficientCommitments = append(pc.coefficientCommitments, npc)
 	}
 
-	return pc
+	return pc, nil
 }
 
 func (pc *PolyCommitment) GetCommitments() []Point {
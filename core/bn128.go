@@ -2,7 +2,9 @@ package core
 
 import (
 	"bytes"
-	"encoding/hex"
+	"crypto/rand"
+	"errors"
+	"fmt"
 	"github.com/hpb-project/HCash-SDK/common"
 	"github.com/hpb-project/HCash-SDK/common/types"
 	"github.com/hpb-project/HCash-SDK/core/bn256"
@@ -14,12 +16,7 @@ import (
 var (
 	FIELD_MODULUS, _      = new(big.Int).SetString("30644e72e131a029b85045b68181585d97816a916871ca8d3c208c16d87cfd47", 16)
 	GROUP_MODULUS, _      = new(big.Int).SetString("30644e72e131a029b85045b68181585d2833e84879b9709143e1f593f0000001", 16)
-	bigzero               = "0x0000000000000000000000000000000000000000000000000000000000000000"
 	B_MAX            uint = 4294967295
-
-	// for test
-	special_Random  = false
-	special_RandomV = ebigint.FromHex("c3f4db6cd90e04d6e086f73fdb7a4ccaa4f57e48593d80c11c0fdd1fcac348df").ToRed(b128.Q())
 )
 
 type BN128 struct {
@@ -59,6 +56,18 @@ func (p Point) Neg() Point {
 	return newPoint(np)
 }
 
+// MultiExp returns the sum of points[i]*scalars[i], computed in one
+// multi-scalar multiplication.
+func MultiExp(points []Point, scalars []*ebigint.NBigInt) Point {
+	var ps = make([]*bn256.G1, len(points))
+	var ks = make([]*big.Int, len(points))
+	for i := range points {
+		ps[i] = points[i].p
+		ks[i] = scalars[i].Int
+	}
+	return newPoint(new(bn256.G1).MultiScalarMult(ps, ks))
+}
+
 func (p Point) XY() (*big.Int, *big.Int) {
 	if p.p != nil {
 		data := p.p.Marshal()
@@ -71,11 +80,7 @@ func (p Point) XY() (*big.Int, *big.Int) {
 }
 
 func (p Point) String() string {
-	data := p.p.Marshal()
-	x := data[0:32]
-	y := data[32:]
-	up := types.Point{hex.EncodeToString(x), hex.EncodeToString(y)}
-	return up.String()
+	return b128.Serialize(p).String()
 }
 
 func NewPoint(d1, d2 *big.Int) Point {
@@ -87,6 +92,19 @@ func NewPoint(d1, d2 *big.Int) Point {
 	return Point{g}
 }
 
+// pointFromBytes decodes a 64 bytes x||y representation, as used inside
+// the serialized proofs, into a Point. (0, 0) is the point at infinity.
+func pointFromBytes(data []byte) (Point, error) {
+	if len(data) != 64 {
+		return Point{}, errors.New("invalid point length")
+	}
+	g := new(bn256.G1)
+	if _, err := g.Unmarshal(data); err != nil {
+		return Point{}, err
+	}
+	return Point{g}, nil
+}
+
 func BytePadding(data []byte, length int) []byte {
 	datalen := len(data)
 	ret := make([]byte, length)
@@ -146,16 +164,14 @@ func (b *BN128) Zero() Point {
 	return Point{data}
 }
 
+// RandomScalar returns a random non-zero scalar read from crypto/rand, use
+// RandomScalarFrom to draw it from another source.
 func (b *BN128) RandomScalar() *ebigint.NBigInt {
-	if special_Random {
-		// for test code for zether_test
-		return special_RandomV
-	} else {
-		fq := bn256.NewFq(b.Q().Int)
-		r, _ := fq.Rand()
-		nr := ebigint.ToNBigInt(r).ForceRed(b.Q())
-		return nr
+	r, err := RandomScalarFrom(rand.Reader)
+	if err != nil {
+		panic(err)
 	}
+	return r
 }
 
 func (b *BN128) Bytes(i *big.Int) string {
@@ -168,37 +184,36 @@ func (b *BN128) B_MAX() uint {
 }
 
 func (b *BN128) Serialize(p Point) types.Point {
-	var x, y string
-	gx, gy := p.XY()
-	if gx == nil || gy == nil {
-		x = bigzero
-		y = bigzero
-	} else {
-		x = b.Bytes(gx)
-		y = b.Bytes(gy)
+	var result types.Point
+	if gx, _ := p.XY(); gx != nil {
+		copy(result[:], p.p.Marshal())
 	}
-	return types.Point{x, y}
+	return result
 }
 
+// UnSerialize converts a types.Point, which is always valid once decoded,
+// into a Point. It panics on a Point that was filled in by hand with bytes
+// that are not on the curve, use DecodePoint for those.
 func (b *BN128) UnSerialize(pubkey types.Point) Point {
-	x := pubkey.GX()
-	y := pubkey.GY()
-	if x == bigzero && y == bigzero {
-		return b.Zero()
-	} else {
-		d1, _ := big.NewInt(0).SetString(x[2:], 16)
-		d2, _ := big.NewInt(0).SetString(y[2:], 16)
-		return NewPoint(d1, d2)
+	p, err := b.DecodePoint(pubkey)
+	if err != nil {
+		panic(err)
+	}
+	return p
+}
+
+// DecodePoint converts a types.Point into a Point, checking that the
+// coordinates are below the field modulus and the point is on the curve.
+// (0, 0) is the point at infinity.
+func (b *BN128) DecodePoint(pubkey types.Point) (Point, error) {
+	p, err := pointFromBytes(pubkey[:])
+	if err != nil {
+		return Point{}, fmt.Errorf("%w: %s", ErrInvalidPoint, err.Error())
 	}
+	return p, nil
 }
 
 func (b *BN128) Representation(p Point) string {
 	key := b.Serialize(p)
 	return "0x" + key.GX()[2:] + key.GY()[2:]
 }
-
-// for test.
-func (b *BN128) SetSpecialRandom(r *ebigint.NBigInt) {
-	special_Random = true
-	special_RandomV = r
-}
@@ -20,8 +20,7 @@ func TestToRed(t *testing.T) {
 }
 
 func TestKeyPair(t *testing.T) {
-	var expect_pubkey = types.Point{"0x124c032852ddfcea7e3bdfa7085a8ad013962decab4c230941417d8f859a7e57",
-		"0x21af1d2346d59bff8237a442e4464977411496b4bb466a48a058b773874bbea1"}
+	var expect_pubkey = types.MustPoint("0x124c032852ddfcea7e3bdfa7085a8ad013962decab4c230941417d8f859a7e57", "0x21af1d2346d59bff8237a442e4464977411496b4bb466a48a058b773874bbea1")
 	var secret = "0x100a1080a8128d4b966bbe15243b9e776db08603f1a36f6d02071fa58d1d5b32"
 	x := ebigint.FromBytes(common.FromHex(secret[2:])).ToRed(b128.Q())
 	curve_g := b128.Serialize(b128.CurveG())
@@ -0,0 +1,78 @@
+package bn256
+
+import (
+	"errors"
+	"math/big"
+)
+
+// CompressedG1Size is the length of MarshalCompressed: a tag byte and x.
+const CompressedG1Size = 1 + 256/8
+
+// The tag byte of a compressed point is 0x02 or 0x03 for an even or odd y,
+// as in SEC 1. The point at infinity is all zeros.
+const (
+	compressedEven = 0x02
+	compressedOdd  = 0x03
+)
+
+// pPlus1Over4 is (p+1)/4. p = 3 mod 4, so a^((p+1)/4) is a square root of a
+// whenever a is a square.
+var pPlus1Over4 = new(big.Int).Rsh(new(big.Int).Add(P, big.NewInt(1)), 2)
+
+// MarshalCompressed converts e to 33 bytes: the parity of y and x.
+func (e *G1) MarshalCompressed() []byte {
+	const numBytes = 256 / 8
+
+	ret := make([]byte, CompressedG1Size)
+	m := e.Marshal()
+	if e.p.IsInfinity() {
+		return ret
+	}
+	ret[0] = compressedEven
+	if m[2*numBytes-1]&1 == 1 {
+		ret[0] = compressedOdd
+	}
+	copy(ret[1:], m[:numBytes])
+	return ret
+}
+
+// UnmarshalCompressed sets e to the point encoded by MarshalCompressed,
+// recovering y from x and the parity bit, and returns the rest of m.
+func (e *G1) UnmarshalCompressed(m []byte) ([]byte, error) {
+	const numBytes = 256 / 8
+	if len(m) < CompressedG1Size {
+		return nil, errors.New("bn256: not enough data")
+	}
+	var full = make([]byte, 2*numBytes)
+	switch m[0] {
+	case 0:
+		for _, b := range m[1:CompressedG1Size] {
+			if b != 0 {
+				return nil, errors.New("bn256: malformed point")
+			}
+		}
+	case compressedEven, compressedOdd:
+		x := new(big.Int).SetBytes(m[1:CompressedG1Size])
+		if x.Cmp(P) >= 0 {
+			return nil, errors.New("bn256: coordinate exceeds modulus")
+		}
+		// y² = x³ + 3
+		y2 := new(big.Int).Exp(x, big.NewInt(3), P)
+		y2.Add(y2, big.NewInt(3)).Mod(y2, P)
+		y := new(big.Int).Exp(y2, pPlus1Over4, P)
+		if new(big.Int).Exp(y, big.NewInt(2), P).Cmp(y2) != 0 {
+			return nil, errors.New("bn256: malformed point")
+		}
+		if y.Bit(0) != uint(m[0]&1) {
+			y.Sub(P, y)
+		}
+		x.FillBytes(full[:numBytes])
+		y.FillBytes(full[numBytes:])
+	default:
+		return nil, errors.New("bn256: unknown point tag")
+	}
+	if _, err := e.Unmarshal(full); err != nil {
+		return nil, err
+	}
+	return m[CompressedG1Size:], nil
+}
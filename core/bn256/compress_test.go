@@ -0,0 +1,66 @@
+package bn256
+
+import (
+	"bytes"
+	"crypto/rand"
+	"math/big"
+	"testing"
+)
+
+func TestG1MarshalCompressed(t *testing.T) {
+	for i := 0; i < 16; i++ {
+		_, Ga, err := RandomG1(rand.Reader)
+		if err != nil {
+			t.Fatal(err)
+		}
+		c := Ga.MarshalCompressed()
+		if len(c) != CompressedG1Size {
+			t.Fatalf("compressed length %d", len(c))
+		}
+		Gb := new(G1)
+		rest, err := Gb.UnmarshalCompressed(append(c, 0xff))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(rest, []byte{0xff}) {
+			t.Fatal("rest of the input not returned")
+		}
+		if !bytes.Equal(Ga.Marshal(), Gb.Marshal()) {
+			t.Fatal("points are different")
+		}
+	}
+
+	inf := new(G1).ScalarBaseMult(big.NewInt(0))
+	c := inf.MarshalCompressed()
+	if !bytes.Equal(c, make([]byte, CompressedG1Size)) {
+		t.Fatal("infinity is not all zeros")
+	}
+	if _, err := new(G1).UnmarshalCompressed(c); err != nil {
+		t.Fatal(err)
+	}
+
+	// g = (1, 2), the negation has the odd y.
+	g := new(G1).ScalarBaseMult(big.NewInt(1))
+	if c := g.MarshalCompressed(); c[0] != 0x02 || c[32] != 1 {
+		t.Fatalf("generator compressed to %x", c)
+	}
+	if c := new(G1).Neg(g).MarshalCompressed(); c[0] != 0x03 {
+		t.Fatalf("negated generator compressed to %x", c)
+	}
+}
+
+func TestG1UnmarshalCompressedInvalid(t *testing.T) {
+	var cases = [][]byte{
+		make([]byte, CompressedG1Size-1),
+		append([]byte{0x04}, make([]byte, 32)...),
+		append([]byte{0x00}, append(make([]byte, 31), 1)...),
+		append([]byte{0x02}, P.Bytes()...),
+	}
+	// x = 0 gives y² = 3, which is not a square.
+	cases = append(cases, append([]byte{0x02}, make([]byte, 32)...))
+	for i, c := range cases {
+		if _, err := new(G1).UnmarshalCompressed(c); err == nil {
+			t.Errorf("case %d: invalid encoding accepted", i)
+		}
+	}
+}
@@ -0,0 +1,64 @@
+package bn256
+
+import (
+	"math/big"
+)
+
+// g1TableWindow is the window size in bits of a G1Table. 6 bits keep a
+// table at 43*63 points while a multiplication needs only 43 additions.
+const g1TableWindow = 6
+
+// G1Table holds the multiples j*2^(6i)*a of a fixed point a, so that a*k
+// becomes one addition per window of k instead of a full double-and-add.
+type G1Table struct {
+	windows [][]curvePoint
+}
+
+// NewG1Table precomputes the table of a.
+func NewG1Table(a *G1) *G1Table {
+	var count = (Order.BitLen() + g1TableWindow - 1) / g1TableWindow
+	t := &G1Table{windows: make([][]curvePoint, count)}
+
+	base, tmp := &curvePoint{}, &curvePoint{}
+	base.Set(a.p)
+	for i := 0; i < count; i++ {
+		window := make([]curvePoint, 1<<g1TableWindow-1)
+		window[0].Set(base)
+		for j := 1; j < len(window); j++ {
+			window[j].Add(&window[j-1], base)
+		}
+		t.windows[i] = window
+
+		for j := 0; j < g1TableWindow; j++ {
+			tmp.Double(base)
+			base.Set(tmp)
+		}
+	}
+	return t
+}
+
+// ScalarMultTable sets e to a*k, where a is the point of the table t, and then
+// returns e.
+func (e *G1) ScalarMultTable(t *G1Table, k *big.Int) *G1 {
+	if e.p == nil {
+		e.p = &curvePoint{}
+	}
+	if k.Sign() < 0 || k.Cmp(Order) >= 0 {
+		k = new(big.Int).Mod(k, Order)
+	}
+
+	sum, tmp := &curvePoint{}, &curvePoint{}
+	sum.SetInfinity()
+	for i, window := range t.windows {
+		idx := 0
+		for j := g1TableWindow - 1; j >= 0; j-- {
+			idx = idx<<1 | int(k.Bit(i*g1TableWindow+j))
+		}
+		if idx != 0 {
+			tmp.Add(sum, &window[idx-1])
+			sum.Set(tmp)
+		}
+	}
+	e.p.Set(sum)
+	return e
+}
@@ -0,0 +1,52 @@
+package bn256
+
+import (
+	"bytes"
+	"crypto/rand"
+	"math/big"
+	"testing"
+)
+
+func TestScalarMultTable(t *testing.T) {
+	_, a, err := RandomG1(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	table := NewG1Table(a)
+
+	var scalars = []*big.Int{
+		big.NewInt(0),
+		big.NewInt(1),
+		big.NewInt(-5),
+		new(big.Int).Sub(Order, big.NewInt(1)),
+		new(big.Int).Add(Order, big.NewInt(7)),
+	}
+	for i := 0; i < 16; i++ {
+		k, _ := rand.Int(rand.Reader, Order)
+		scalars = append(scalars, k)
+	}
+	for _, k := range scalars {
+		got := new(G1).ScalarMultTable(table, k).Marshal()
+		want := new(G1).ScalarMult(a, new(big.Int).Mod(k, Order)).Marshal()
+		if !bytes.Equal(got, want) {
+			t.Errorf("k = %v: table mult mismatch", k)
+		}
+	}
+}
+
+func BenchmarkScalarMultTable(b *testing.B) {
+	_, a, _ := RandomG1(rand.Reader)
+	table := NewG1Table(a)
+	k, _ := rand.Int(rand.Reader, Order)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		new(G1).ScalarMultTable(table, k)
+	}
+}
+
+func BenchmarkNewG1Table(b *testing.B) {
+	_, a, _ := RandomG1(rand.Reader)
+	for i := 0; i < b.N; i++ {
+		NewG1Table(a)
+	}
+}
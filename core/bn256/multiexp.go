@@ -0,0 +1,84 @@
+package bn256
+
+import (
+	"math/big"
+	"math/bits"
+)
+
+// MultiScalarMult sets e to the sum of a[i]*k[i] and then returns e. It uses
+// Pippenger's bucket method, which needs far fewer group operations than
+// one ScalarMult per term once there are more than a handful of terms.
+func (e *G1) MultiScalarMult(a []*G1, k []*big.Int) *G1 {
+	if len(a) != len(k) {
+		panic("bn256: mismatched number of points and scalars")
+	}
+	if e.p == nil {
+		e.p = &curvePoint{}
+	}
+
+	if len(a) < 4 {
+		sum, t, u := &curvePoint{}, &curvePoint{}, &curvePoint{}
+		sum.SetInfinity()
+		for i := range a {
+			t.Mul(a[i].p, k[i])
+			u.Add(sum, t)
+			sum.Set(u)
+		}
+		e.p.Set(sum)
+		return e
+	}
+
+	var scalars = make([]*big.Int, len(k))
+	var maxBits = 0
+	for i := range k {
+		scalars[i] = k[i]
+		if k[i].Sign() < 0 || k[i].Cmp(Order) >= 0 {
+			scalars[i] = new(big.Int).Mod(k[i], Order)
+		}
+		if l := scalars[i].BitLen(); l > maxBits {
+			maxBits = l
+		}
+	}
+
+	c := bits.Len(uint(len(a)))/2 + 1
+	windows := (maxBits + c - 1) / c
+	buckets := make([]curvePoint, 1<<uint(c)-1)
+
+	sum, t := &curvePoint{}, &curvePoint{}
+	running, window := &curvePoint{}, &curvePoint{}
+	sum.SetInfinity()
+	for w := windows - 1; w >= 0; w-- {
+		for j := 0; j < c; j++ {
+			t.Double(sum)
+			sum.Set(t)
+		}
+
+		for j := range buckets {
+			buckets[j].SetInfinity()
+		}
+		for i, s := range scalars {
+			idx := 0
+			for j := c - 1; j >= 0; j-- {
+				idx = idx<<1 | int(s.Bit(w*c+j))
+			}
+			if idx != 0 {
+				t.Add(&buckets[idx-1], a[i].p)
+				buckets[idx-1].Set(t)
+			}
+		}
+
+		// sum of (j+1)*buckets[j], by running sums from the top bucket.
+		running.SetInfinity()
+		window.SetInfinity()
+		for j := len(buckets) - 1; j >= 0; j-- {
+			t.Add(running, &buckets[j])
+			running.Set(t)
+			t.Add(window, running)
+			window.Set(t)
+		}
+		t.Add(sum, window)
+		sum.Set(t)
+	}
+	e.p.Set(sum)
+	return e
+}
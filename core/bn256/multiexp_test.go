@@ -0,0 +1,68 @@
+package bn256
+
+import (
+	"bytes"
+	"crypto/rand"
+	"math/big"
+	"testing"
+)
+
+func naiveMultiScalarMult(a []*G1, k []*big.Int) *G1 {
+	sum := new(G1).ScalarBaseMult(big.NewInt(0))
+	for i := range a {
+		sum = new(G1).Add(sum, new(G1).ScalarMult(a[i], k[i]))
+	}
+	return sum
+}
+
+func randomTerms(t testing.TB, n int) ([]*G1, []*big.Int) {
+	a := make([]*G1, n)
+	k := make([]*big.Int, n)
+	for i := 0; i < n; i++ {
+		_, p, err := RandomG1(rand.Reader)
+		if err != nil {
+			t.Fatal(err)
+		}
+		s, _, err := RandomG1(rand.Reader)
+		if err != nil {
+			t.Fatal(err)
+		}
+		a[i], k[i] = p, s
+	}
+	return a, k
+}
+
+func TestMultiScalarMult(t *testing.T) {
+	for _, n := range []int{0, 1, 3, 4, 5, 17, 64, 130} {
+		a, k := randomTerms(t, n)
+		if n > 4 {
+			// repeated points, zero, negative and oversized scalars.
+			a[1] = a[0]
+			k[1] = k[0]
+			k[2] = big.NewInt(0)
+			k[3] = new(big.Int).Neg(k[3])
+			k[4] = new(big.Int).Add(k[4], Order)
+		}
+		got := new(G1).MultiScalarMult(a, k).Marshal()
+		want := naiveMultiScalarMult(a, k).Marshal()
+		if !bytes.Equal(got, want) {
+			t.Errorf("n = %d: multi scalar mult mismatch", n)
+		}
+	}
+}
+
+func BenchmarkMultiScalarMult64(b *testing.B) {
+	a, k := randomTerms(b, 64)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		new(G1).MultiScalarMult(a, k)
+	}
+}
+
+func BenchmarkNaiveMultiScalarMult64(b *testing.B) {
+	a, k := randomTerms(b, 64)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		naiveMultiScalarMult(a, k)
+	}
+}
@@ -0,0 +1,40 @@
+package bn256
+
+// NormalizeG1 converts every point of a to affine coordinates, sharing a
+// single field inversion between all of them (Montgomery's trick). It saves
+// the inversion Marshal would otherwise do per point.
+func NormalizeG1(a []*G1) {
+	var prefix = make([]gfP, len(a))
+	acc := *newGFp(1)
+	for i, e := range a {
+		prefix[i] = acc
+		if e.p != nil && !e.p.IsInfinity() {
+			gfpMul(&acc, &acc, &e.p.z)
+		}
+	}
+
+	inv := &gfP{}
+	inv.Invert(&acc)
+
+	zInv, zInv2 := &gfP{}, &gfP{}
+	for i := len(a) - 1; i >= 0; i-- {
+		c := a[i].p
+		if c == nil {
+			continue
+		}
+		if c.IsInfinity() {
+			c.MakeAffine()
+			continue
+		}
+		// inv is 1/(z_0 * .. * z_i) here.
+		gfpMul(zInv, inv, &prefix[i])
+		gfpMul(inv, inv, &c.z)
+
+		gfpMul(zInv2, zInv, zInv)
+		gfpMul(&c.x, &c.x, zInv2)
+		gfpMul(zInv2, zInv2, zInv)
+		gfpMul(&c.y, &c.y, zInv2)
+		c.z = *newGFp(1)
+		c.t = *newGFp(1)
+	}
+}
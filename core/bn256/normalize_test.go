@@ -0,0 +1,29 @@
+package bn256
+
+import (
+	"bytes"
+	"math/big"
+	"testing"
+)
+
+func TestNormalizeG1(t *testing.T) {
+	a, k := randomTerms(t, 9)
+	var points = make([]*G1, len(a))
+	var want = make([][]byte, len(a))
+	for i := range a {
+		points[i] = new(G1).ScalarMult(a[i], k[i])
+		if i == 4 {
+			points[i] = new(G1).ScalarMult(a[i], big.NewInt(0))
+		}
+		want[i] = new(G1).Set(points[i]).Marshal()
+	}
+	NormalizeG1(points)
+	for i, p := range points {
+		if !p.p.IsInfinity() && p.p.z != *newGFp(1) {
+			t.Errorf("point %d not affine", i)
+		}
+		if !bytes.Equal(p.Marshal(), want[i]) {
+			t.Errorf("point %d changed by normalization", i)
+		}
+	}
+}
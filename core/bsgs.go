@@ -0,0 +1,204 @@
+package core
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+
+	"github.com/hpb-project/HCash-SDK/common/types"
+	"github.com/hpb-project/HCash-SDK/core/ebigint"
+)
+
+const (
+	// DefaultBalanceTableSize is the number of baby steps of the default
+	// table, a balance of B_MAX needs at most 2^16 giant steps with it.
+	DefaultBalanceTableSize uint32 = 1 << 16
+
+	balanceTableMagic = "HCBT"
+)
+
+var (
+	ErrBalanceNotFound    = errors.New("balance not found in [0, B_MAX]")
+	ErrInvalidTableSize   = errors.New("invalid balance table size")
+	ErrInvalidTableFormat = errors.New("invalid balance table format")
+
+	defaultBalanceTable     *BalanceTable
+	defaultBalanceTableLock sync.Mutex
+)
+
+// BalanceTable solves g^b = gB for b in [0, B_MAX] with baby-step
+// giant-step. The baby steps g^0 .. g^(size-1) are indexed by the low 8
+// bytes of their x coordinate, every hit is confirmed before it is returned.
+type BalanceTable struct {
+	size  uint32
+	baby  map[uint64]uint32
+	giant Point // g^(-size)
+}
+
+func pointKey(p Point) uint64 {
+	data := p.p.Marshal()
+	return binary.BigEndian.Uint64(data[24:32])
+}
+
+// NewBalanceTable computes a table with size baby steps, which makes a
+// lookup cost at most (B_MAX+1)/size giant steps.
+func NewBalanceTable(size uint32) (*BalanceTable, error) {
+	if size == 0 {
+		return nil, ErrInvalidTableSize
+	}
+	t := &BalanceTable{size: size, baby: make(map[uint64]uint32, size)}
+	var accumulator = b128.Zero()
+	for j := uint32(0); j < size; j++ {
+		t.baby[pointKey(accumulator)] = j
+		accumulator = accumulator.Add(b128.CurveG())
+	}
+	t.giant = accumulator.Neg()
+	return t, nil
+}
+
+func (t *BalanceTable) Size() uint32 {
+	return t.size
+}
+
+// Solve returns b with g^b == gB.
+func (t *BalanceTable) Solve(gB Point) (uint32, error) {
+	var steps = (uint64(B_MAX) + uint64(t.size)) / uint64(t.size)
+	var gamma = gB
+	for i := uint64(0); i < steps; i++ {
+		if j, ok := t.baby[pointKey(gamma)]; ok {
+			var b = i*uint64(t.size) + uint64(j)
+			if b <= uint64(B_MAX) && b128.CurveG().Mul(ebigint.NewNBigInt(int64(b))).Equal(gB) {
+				return uint32(b), nil
+			}
+		}
+		gamma = gamma.Add(t.giant)
+	}
+	return 0, ErrBalanceNotFound
+}
+
+// ReadBalance decrypts the (CL, CR) account pair with secret x.
+func (t *BalanceTable) ReadBalance(CL, CR types.Point, x *ebigint.NBigInt) (uint32, error) {
+	nCL := b128.UnSerialize(CL)
+	nCR := b128.UnSerialize(CR)
+
+	var gB = nCL.Add(nCR.Mul(x.RedNeg()))
+	return t.Solve(gB)
+}
+
+// ReadBalances decrypts every pair of accounts with the same secret x,
+// sharing one table.
+func (t *BalanceTable) ReadBalances(accounts [][2]types.Point, x *ebigint.NBigInt) ([]uint32, error) {
+	var result = make([]uint32, len(accounts))
+	for i, account := range accounts {
+		b, err := t.ReadBalance(account[0], account[1], x)
+		if err != nil {
+			return nil, fmt.Errorf("account %d: %s", i, err.Error())
+		}
+		result[i] = b
+	}
+	return result, nil
+}
+
+// WriteTo stores the table as "HCBT", the size, then the key of every baby
+// step in order, so that LoadBalanceTable can skip the precomputation.
+func (t *BalanceTable) WriteTo(w io.Writer) (int64, error) {
+	var keys = make([]uint64, t.size)
+	for k, j := range t.baby {
+		keys[j] = k
+	}
+	bw := bufio.NewWriter(w)
+	var n int64
+	var buf [8]byte
+	bw.WriteString(balanceTableMagic)
+	n += 4
+	binary.BigEndian.PutUint32(buf[:4], t.size)
+	bw.Write(buf[:4])
+	n += 4
+	for _, k := range keys {
+		binary.BigEndian.PutUint64(buf[:], k)
+		bw.Write(buf[:])
+		n += 8
+	}
+	return n, bw.Flush()
+}
+
+// LoadBalanceTable reads a table written by WriteTo. The first and last
+// entries are checked against a fresh computation.
+func LoadBalanceTable(r io.Reader) (*BalanceTable, error) {
+	br := bufio.NewReader(r)
+	var header [8]byte
+	if _, err := io.ReadFull(br, header[:]); err != nil {
+		return nil, ErrInvalidTableFormat
+	}
+	if string(header[:4]) != balanceTableMagic {
+		return nil, ErrInvalidTableFormat
+	}
+	var size = binary.BigEndian.Uint32(header[4:])
+	if size == 0 {
+		return nil, ErrInvalidTableSize
+	}
+
+	t := &BalanceTable{size: size, baby: make(map[uint64]uint32, size)}
+	var buf [8]byte
+	var last uint64
+	for j := uint32(0); j < size; j++ {
+		if _, err := io.ReadFull(br, buf[:]); err != nil {
+			return nil, ErrInvalidTableFormat
+		}
+		last = binary.BigEndian.Uint64(buf[:])
+		t.baby[last] = j
+	}
+	if j, ok := t.baby[pointKey(b128.Zero())]; !ok || j != 0 {
+		return nil, ErrInvalidTableFormat
+	}
+	var top = b128.CurveG().Mul(ebigint.NewNBigInt(int64(size - 1)))
+	if pointKey(top) != last {
+		return nil, ErrInvalidTableFormat
+	}
+	t.giant = top.Add(b128.CurveG()).Neg()
+	return t, nil
+}
+
+func (t *BalanceTable) SaveFile(path string) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if _, err = t.WriteTo(f); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+func LoadBalanceTableFile(path string) (*BalanceTable, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return LoadBalanceTable(f)
+}
+
+// SetDefaultBalanceTable replaces the table used by ReadBalance, e.g. with
+// a larger one loaded from disk.
+func SetDefaultBalanceTable(t *BalanceTable) {
+	defaultBalanceTableLock.Lock()
+	defer defaultBalanceTableLock.Unlock()
+	defaultBalanceTable = t
+}
+
+// DefaultBalanceTable returns the table used by ReadBalance, it is built
+// with DefaultBalanceTableSize on first use.
+func DefaultBalanceTable() *BalanceTable {
+	defaultBalanceTableLock.Lock()
+	defer defaultBalanceTableLock.Unlock()
+	if defaultBalanceTable == nil {
+		defaultBalanceTable, _ = NewBalanceTable(DefaultBalanceTableSize)
+	}
+	return defaultBalanceTable
+}
@@ -0,0 +1,75 @@
+package core
+
+import (
+	"bytes"
+	"path/filepath"
+	"testing"
+
+	"github.com/hpb-project/HCash-SDK/common/types"
+	"github.com/hpb-project/HCash-SDK/core/ebigint"
+	"gotest.tools/assert"
+)
+
+func encryptBalance(b uint32, y Point, r *ebigint.NBigInt) [2]types.Point {
+	CL := b128.CurveG().Mul(ebigint.NewNBigInt(int64(b))).Add(y.Mul(r))
+	CR := b128.CurveG().Mul(r)
+	return [2]types.Point{b128.Serialize(CL), b128.Serialize(CR)}
+}
+
+func TestBalanceTable(t *testing.T) {
+	x := ebigint.FromHex("20a89bb465e9e2262e25901525509686f6a26b2fba976f1d9ff00a0cdbb362b0").ToRed(b128.Q())
+	y := b128.CurveG().Mul(x)
+	r := ebigint.FromHex("2296c63311038849058a5a831a333ee2c3643aba005bf3cf98dd4c6972a79d1d").ToRed(b128.Q())
+
+	table, err := NewBalanceTable(1 << 12)
+	assert.NilError(t, err)
+
+	var values = []uint32{0, 1, 4095, 4096, 123456789}
+	var accounts [][2]types.Point
+	for _, v := range values {
+		accounts = append(accounts, encryptBalance(v, y, r))
+	}
+	balances, err := table.ReadBalances(accounts, x)
+	assert.NilError(t, err)
+	assert.DeepEqual(t, balances, values)
+
+	big := encryptBalance(uint32(B_MAX), y, r)
+	b, err := DefaultBalanceTable().ReadBalance(big[0], big[1], x)
+	assert.NilError(t, err)
+	assert.Equal(t, b, uint32(B_MAX))
+
+	// another secret decrypts to a point outside of [0, B_MAX].
+	_, err = DefaultBalanceTable().ReadBalance(big[0], big[1], x.RedAdd(ebigint.NewNBigInt(1).ToRed(b128.Q())))
+	assert.Equal(t, err, ErrBalanceNotFound)
+
+	_, err = NewBalanceTable(0)
+	assert.Equal(t, err, ErrInvalidTableSize)
+}
+
+func TestBalanceTableFile(t *testing.T) {
+	table, err := NewBalanceTable(1 << 10)
+	assert.NilError(t, err)
+
+	var buf bytes.Buffer
+	n, err := table.WriteTo(&buf)
+	assert.NilError(t, err)
+	assert.Equal(t, n, int64(8+8<<10))
+
+	loaded, err := LoadBalanceTable(bytes.NewReader(buf.Bytes()))
+	assert.NilError(t, err)
+	assert.DeepEqual(t, loaded.baby, table.baby)
+	assert.Assert(t, loaded.giant.Equal(table.giant))
+
+	corrupted := append([]byte{}, buf.Bytes()...)
+	corrupted[len(corrupted)-1] ^= 1
+	_, err = LoadBalanceTable(bytes.NewReader(corrupted))
+	assert.Equal(t, err, ErrInvalidTableFormat)
+	_, err = LoadBalanceTable(bytes.NewReader(buf.Bytes()[:100]))
+	assert.Equal(t, err, ErrInvalidTableFormat)
+
+	path := filepath.Join(t.TempDir(), "balance.tbl")
+	assert.NilError(t, table.SaveFile(path))
+	loaded, err = LoadBalanceTableFile(path)
+	assert.NilError(t, err)
+	assert.Equal(t, loaded.Size(), uint32(1<<10))
+}
@@ -1,15 +1,16 @@
 package core
 
 import (
+	"crypto/rand"
 	"encoding/hex"
-	"errors"
 	"fmt"
 	"github.com/hpb-project/HCash-SDK/common"
-	"log"
+	"io"
 	"math/big"
 	"strings"
 
 	"github.com/ethereum/go-ethereum/accounts/abi"
+	ethcommon "github.com/ethereum/go-ethereum/common"
 	"github.com/hpb-project/HCash-SDK/core/ebigint"
 )
 
@@ -30,13 +31,23 @@ type BurnProof struct {
 }
 
 func (z BurnProof) Serialize() string {
+	return z.serialize(b128.Representation)
+}
+
+// SerializeCompressed is Serialize with every point in the 33 bytes
+// compressed form, use DecompressBurnProof before sending it on chain.
+func (z BurnProof) SerializeCompressed() string {
+	return z.serialize(b128.CompressedRepresentation)
+}
+
+func (z BurnProof) serialize(point func(Point) string) string {
 	result := "0x"
-	result += b128.Representation(z.BA)[2:]
-	result += b128.Representation(z.BS)[2:]
+	result += point(z.BA)[2:]
+	result += point(z.BS)[2:]
 
 	tcv := z.tCommits.GetVector()
 	for _, commit := range tcv {
-		result += b128.Representation(commit)[2:]
+		result += point(commit)[2:]
 	}
 
 	result += b128.Bytes(z.tHat.Int)[2:]
@@ -46,24 +57,93 @@ func (z BurnProof) Serialize() string {
 	result += b128.Bytes(z.s_b.Int)[2:]
 	result += b128.Bytes(z.s_tau.Int)[2:]
 
-	result += z.ipProof.Serialize()[2:]
+	result += z.ipProof.serialize(point)[2:]
 
 	return result
 }
 
+// UnserializeBurnProof is the inverse of Serialize.
+func UnserializeBurnProof(proof string) (*BurnProof, error) {
+	reader, err := newProofReader(proof)
+	if err != nil {
+		return nil, err
+	}
+	return unserializeBurnProof(reader)
+}
+
+// UnserializeBurnProofCompressed is the inverse of SerializeCompressed.
+func UnserializeBurnProofCompressed(proof string) (*BurnProof, error) {
+	reader, err := newCompressedProofReader(proof)
+	if err != nil {
+		return nil, err
+	}
+	return unserializeBurnProof(reader)
+}
+
+func unserializeBurnProof(reader *proofReader) (*BurnProof, error) {
+	if want := reader.size(14, 8); reader.Len() != want {
+		return nil, fmt.Errorf("invalid burn proof length %d, want %d", reader.Len(), want)
+	}
+
+	var err error
+	z := &BurnProof{}
+	if z.BA, err = reader.readPoint(); err != nil {
+		return nil, err
+	}
+	if z.BS, err = reader.readPoint(); err != nil {
+		return nil, err
+	}
+	tCommits, err := reader.readPoints(2)
+	if err != nil {
+		return nil, err
+	}
+	z.tCommits = NewGeneratorVector(tCommits)
+	for _, e := range []**ebigint.NBigInt{&z.tHat, &z.mu, &z.c, &z.s_sk, &z.s_b, &z.s_tau} {
+		if *e, err = reader.readScalar(); err != nil {
+			return nil, err
+		}
+	}
+
+	if z.ipProof, err = unserializeInnerProductProof(reader, 5); err != nil {
+		return nil, err
+	}
+	if err = reader.finish(); err != nil {
+		return nil, err
+	}
+	return z, nil
+}
+
+func (z *BurnProof) GetBA() Point                   { return z.BA }
+func (z *BurnProof) GetBS() Point                   { return z.BS }
+func (z *BurnProof) GetTCommits() *GeneratorVector  { return z.tCommits }
+func (z *BurnProof) GetTHat() *ebigint.NBigInt      { return z.tHat }
+func (z *BurnProof) GetMu() *ebigint.NBigInt        { return z.mu }
+func (z *BurnProof) GetC() *ebigint.NBigInt         { return z.c }
+func (z *BurnProof) GetS_sk() *ebigint.NBigInt      { return z.s_sk }
+func (z *BurnProof) GetS_b() *ebigint.NBigInt       { return z.s_b }
+func (z *BurnProof) GetS_tau() *ebigint.NBigInt     { return z.s_tau }
+func (z *BurnProof) GetIPProof() *InnerProductProof { return z.ipProof }
+
 type BurnProver struct {
 	params   *GeneratorParams
 	ipProver *InnerProductProver
+	random   io.Reader
 }
 
 func NewBurnProver() BurnProver {
-	params := NewGeneratorParams(int(32), nil, nil)
+	params := GetGeneratorParams(32)
 	return BurnProver{
 		params:   params,
 		ipProver: new(InnerProductProver),
+		random:   rand.Reader,
 	}
 }
 
+// SetRandom sets the source of the blinding scalars, crypto/rand by default.
+func (burn *BurnProver) SetRandom(r io.Reader) {
+	burn.random = r
+}
+
 type interBurnStatement struct {
 	CLn    Point
 	CRn    Point
@@ -74,59 +154,49 @@ type interBurnStatement struct {
 
 type interBurnWitness struct {
 	bDiff *ebigint.NBigInt
-	sk    *ebigint.NBigInt
+	key   KeyHandle
 }
 
 func (burn BurnProver) tointerBurnStatement(istatement BurnStatement) (*interBurnStatement, error) {
+	if !ethcommon.IsHexAddress(istatement.Sender) {
+		return nil, fmt.Errorf("invalid sender address %q", istatement.Sender)
+	}
 	statement := &interBurnStatement{}
 	statement.Epoch = istatement.Epoch
 	statement.Sender = istatement.Sender
 
-	statement.CLn = b128.UnSerialize(istatement.CLn)
-	statement.CRn = b128.UnSerialize(istatement.CRn)
-	statement.Y = b128.UnSerialize(istatement.Y)
-
+	var err error
+	if statement.CLn, err = b128.DecodePoint(istatement.CLn); err != nil {
+		return nil, fmt.Errorf("CLn: %w", err)
+	}
+	if statement.CRn, err = b128.DecodePoint(istatement.CRn); err != nil {
+		return nil, fmt.Errorf("CRn: %w", err)
+	}
+	if statement.Y, err = b128.DecodePoint(istatement.Y); err != nil {
+		return nil, fmt.Errorf("y: %w", err)
+	}
 	return statement, nil
 }
 
 func (burn BurnProver) tointerBurnWitness(iwitness BurnWitness) (*interBurnWitness, error) {
-	witness := &interBurnWitness{}
-	witness.bDiff = ebigint.NewNBigInt(int64(iwitness.BDiff)).ToRed(b128.Q())
-	str_sk := iwitness.SK
-	if strings.HasPrefix(str_sk, "0x") {
-		str_sk = str_sk[2:]
+	if iwitness.BDiff < 0 {
+		return nil, fmt.Errorf("%w: bDiff %d", ErrInsufficientBalance, iwitness.BDiff)
 	}
-	sk, ok := big.NewInt(0).SetString(str_sk, 16)
-	if !ok {
-		return nil, errors.New("witness sk is invalid")
-	} else {
-		witness.sk = ebigint.ToNBigInt(sk).ForceRed(b128.Q())
+	if err := checkValue("bDiff", iwitness.BDiff); err != nil {
+		return nil, err
 	}
-	return witness, nil
-}
+	witness := &interBurnWitness{}
+	witness.bDiff = ebigint.NewNBigInt(int64(iwitness.BDiff)).ToRed(b128.Q())
 
-func (burn BurnProver) GenerateProof(istatement BurnStatement, iwitness BurnWitness) *BurnProof {
-	var proof = &BurnProof{}
 	var err error
-	var statement *interBurnStatement
-	var witness *interBurnWitness
-
-	statement, err = burn.tointerBurnStatement(istatement)
-	if err != nil {
-		log.Printf("to inter burn statement failed, err:%s\n", err.Error())
-		return nil
-	}
-
-	witness, err = burn.tointerBurnWitness(iwitness)
-	if err != nil {
-		log.Printf("to inter burn waitness failed, err:%s\n", err.Error())
-		return nil
+	if witness.key, err = witnessKey(iwitness.Key, iwitness.SK, burn.random); err != nil {
+		return nil, err
 	}
+	return witness, nil
+}
 
-	bytes32_2T, _ := abi.NewType("bytes32[2]", "", nil)
-	uint256_T, _ := abi.NewType("uint256", "", nil)
+func burnStatementHash(istatement BurnStatement, statement *interBurnStatement) *ebigint.NBigInt {
 	address_T, _ := abi.NewType("address", "", nil)
-	bytes32_T, _ := abi.NewType("bytes32", "", nil)
 	arguments := abi.Arguments{
 		{
 			Type: bytes32_2T,
@@ -150,16 +220,41 @@ func (burn BurnProver) GenerateProof(istatement BurnStatement, iwitness BurnWitn
 
 	epoch := new(big.Int).SetInt64(int64(istatement.Epoch))
 
-	bytes, err := arguments.Pack(
+	bytes, _ := arguments.Pack(
 		parsePoint2ABI_Bytes32_2(statement.CLn),
 		parsePoint2ABI_Bytes32_2(statement.CRn),
 		parsePoint2ABI_Bytes32_2(statement.Y),
 		epoch,
 		addr)
-	//fmt.Println("statement addr = ", hex.EncodeToString(addr[:]))
 	strbytes := hex.EncodeToString(bytes)
 
-	var statementHash = Hash(strbytes)
+	return Hash(strbytes)
+}
+
+// GenerateProof proves the burn, the statement and witness are checked
+// with the same rules as CheckBurn first.
+func (burn BurnProver) GenerateProof(istatement BurnStatement, iwitness BurnWitness) (*BurnProof, error) {
+	var proof = &BurnProof{}
+	var err error
+	var statement *interBurnStatement
+	var witness *interBurnWitness
+
+	statement, err = burn.tointerBurnStatement(istatement)
+	if err != nil {
+		return nil, err
+	}
+	witness, err = burn.tointerBurnWitness(iwitness)
+	if err != nil {
+		return nil, err
+	}
+	if err = checkBurn(statement, witness); err != nil {
+		return nil, err
+	}
+
+	var statementHash = burnStatementHash(istatement, statement)
+	random := newScalarReader(burn.random)
+	bytes32_2T, _ := abi.NewType("bytes32[2]", "", nil)
+	bytes32_T, _ := abi.NewType("bytes32", "", nil)
 	//fmt.Println("statementhash  = ", statementHash.Text(16))
 	splits := strings.Split(witness.bDiff.Text(2), "")
 
@@ -177,7 +272,7 @@ func (burn BurnProver) GenerateProof(istatement BurnStatement, iwitness BurnWitn
 	var aL = NewFieldVector(nArray)
 	var aR = aL.Plus(ebigint.NewNBigInt(1).ToRed(b128.Q()).RedNeg())
 
-	var alpha = b128.RandomScalar()
+	var alpha = random.next()
 	proof.BA = burn.params.Commit(alpha, aL, aR)
 	fmt.Println("ba=", proof.BA.String())
 
@@ -187,14 +282,14 @@ func (burn BurnProver) GenerateProof(istatement BurnStatement, iwitness BurnWitn
 		var vsR = make([]*ebigint.NBigInt, 32)
 
 		for i := 0; i < 32; i++ {
-			vsL[i] = b128.RandomScalar()
-			vsR[i] = b128.RandomScalar()
+			vsL[i] = random.next()
+			vsR[i] = random.next()
 		}
 		sL = NewFieldVector(vsL)
 		sR = NewFieldVector(vsR)
 	}
 
-	var rho = b128.RandomScalar()
+	var rho = random.next()
 	proof.BS = burn.params.Commit(rho, sL, sR)
 	fmt.Println("bs=", proof.BS.String())
 
@@ -244,7 +339,10 @@ func (burn BurnProver) GenerateProof(istatement BurnStatement, iwitness BurnWitn
 	var rPoly = NewFieldVectorPolynomial(ys.Hadamard(aR.Plus(z)).Add(twoTimesZs), sR.Hadamard(ys))
 	var tPolyCoefficients = lPoly.InnerProduct(rPoly)
 
-	var polyCommitment = NewPolyCommitment(*burn.params, tPolyCoefficients)
+	polyCommitment, err := NewPolyCommitment(*burn.params, tPolyCoefficients, random.r)
+	if err != nil {
+		return nil, err
+	}
 	proof.tCommits = NewGeneratorVector(polyCommitment.GetCommitments()) // just 2 of them
 
 	argumentsx := abi.Arguments{
@@ -275,14 +373,22 @@ func (burn BurnProver) GenerateProof(istatement BurnStatement, iwitness BurnWitn
 	proof.mu = alpha.RedAdd(rho.RedMul(x))
 	fmt.Println("proof.mu=", proof.mu.String())
 
-	var k_sk = b128.RandomScalar()
-	var k_b = b128.RandomScalar()
-	var k_tau = b128.RandomScalar()
+	// k_sk is drawn by the key, A_y, A_u and the sk part of A_b are its
+	// commitments.
+	session, A_sk, err := keyCommit(witness.key, burn.params.GetFixedG().Point(), statement.CRn.Mul(zs[0]), GEpoch(statement.Epoch))
+	if err != nil {
+		return nil, err
+	}
+	var k_b = random.next()
+	var k_tau = random.next()
+	if random.err != nil {
+		return nil, random.err
+	}
 
-	var A_y = burn.params.GetG().Mul(k_sk)
-	var A_b = burn.params.GetG().Mul(k_b).Add(statement.CRn.Mul(zs[0]).Mul(k_sk))
-	var A_t = burn.params.GetG().Mul(k_b.RedNeg()).Add(burn.params.GetH().Mul(k_tau))
-	var A_u = GEpoch(statement.Epoch).Mul(k_sk)
+	var A_y = A_sk[0]
+	var A_b = burn.params.GetFixedG().Mul(k_b).Add(A_sk[1])
+	var A_t = burn.params.GetFixedG().Mul(k_b.RedNeg()).Add(burn.params.GetFixedH().Mul(k_tau))
+	var A_u = A_sk[2]
 
 	argumentsproofc := abi.Arguments{
 		{
@@ -312,7 +418,9 @@ func (burn BurnProver) GenerateProof(istatement BurnStatement, iwitness BurnWitn
 	proof.c = Hash(hex.EncodeToString(cbytes))
 	fmt.Println("proof.c=", proof.c.String())
 
-	proof.s_sk = k_sk.RedAdd(proof.c.RedMul(witness.sk))
+	if proof.s_sk, err = witness.key.Respond(session, proof.c); err != nil {
+		return nil, err
+	}
 	proof.s_b = k_b.RedAdd(proof.c.RedMul(witness.bDiff.RedMul(zs[0])))
 	proof.s_tau = k_tau.RedAdd(proof.c.RedMul(tauX))
 
@@ -321,7 +429,7 @@ func (burn BurnProver) GenerateProof(istatement BurnStatement, iwitness BurnWitn
 	var hExp = ys.Times(z).Add(twoTimesZs)
 
 	var P = proof.BA.Add(proof.BS.Mul(x)).Add(gs.Sum().Mul(z.RedNeg())).Add(hPrimes.Commit(hExp))
-	P = P.Add(burn.params.GetH().Mul(proof.mu.RedNeg())) // Statement P of protocol 1. should this be included in the calculation of v...?
+	P = P.Add(burn.params.GetFixedH().Mul(proof.mu.RedNeg())) // Statement P of protocol 1. should this be included in the calculation of v...?
 
 	argumento := abi.Arguments{
 		{
@@ -333,7 +441,7 @@ func (burn BurnProver) GenerateProof(istatement BurnStatement, iwitness BurnWitn
 	)
 
 	var o = Hash(hex.EncodeToString(obytes))
-	var u_x = burn.params.GetG().Mul(o)
+	var u_x = burn.params.GetFixedG().Mul(o)
 	P = P.Add(u_x.Mul(proof.tHat))
 
 	var primeBase = NewGeneratorParams(u_x, gs, hPrimes)
@@ -346,5 +454,5 @@ func (burn BurnProver) GenerateProof(istatement BurnStatement, iwitness BurnWitn
 	proof.ipProof = burn.ipProver.GenerateProof(ipStatement, ipWitness, o)
 
 	fmt.Println("ipproof=", proof.ipProof.Serialize())
-	return proof
+	return proof, nil
 }
@@ -0,0 +1,74 @@
+package core
+
+import (
+	"fmt"
+	"github.com/hpb-project/HCash-SDK/common/types"
+	"github.com/hpb-project/HCash-SDK/core/ebigint"
+	"gotest.tools/assert"
+	"testing"
+)
+
+func TestVerifyBurn(t *testing.T) {
+	cln := types.MustPoint("0x1418a69e20ab642d7dad6e8080de42a0f6a2110dcb20e35bda8e3a9a47161f26", "0x0207f80673298caa563db3537892881a13d2b234c5e7ab1b5e368ff072542558")
+	crn := types.MustPoint("0x077da99d806abd13c9f15ece5398525119d11e11e9836b2ee7d23f6159ad87d4", "0x01485efa927f2ad41bff567eec88f32fb0a0f706588b4e41a8d587d008b7f875")
+	y := types.MustPoint("0x2af593d93442ca5d86d1f3748e624e68cc7db78da5fa568c40e32753e2e5b64b", "0x301248643b2813c1aaa9fbb7cec25fa6fb8e6d6db1240649b848a545962a9f81")
+	epoch := 53672920
+	sender := "d80ac1fb177c0b8d9c66de2b9657dd57084a2d7f"
+	x := "0x04907c94209e3442e4830c142ba166ac032e511d00fcdf5f01b77d480518fa1a"
+
+	statement := BurnStatement{CLn: cln, CRn: crn, Y: y, Epoch: epoch, Sender: sender}
+	witness := BurnWitness{SK: x, BDiff: 99}
+	proof, err := ProveBurn(statement, witness)
+	assert.NilError(t, err)
+	u := b128.Serialize(U(epoch, ebigint.FromHex(x)))
+	assert.NilError(t, VerifyBurn(statement, u, proof))
+
+	// the proof is bound to the sender.
+	other := statement
+	other.Sender = "e4920905e06c6b6070477c40b85756ffda3cd3e6"
+	assert.Assert(t, VerifyBurn(other, u, proof) != nil)
+
+	// a wrong balance must not verify.
+	other = statement
+	other.CLn = b128.Serialize(b128.UnSerialize(cln).Add(b128.CurveG()))
+	assert.Assert(t, VerifyBurn(other, u, proof) != nil)
+}
+
+// burnVector is the hcash.go burn example with its real balance.
+func burnVector() (BurnStatement, BurnWitness) {
+	statement := BurnStatement{
+		CLn:    types.MustPoint("0x1418a69e20ab642d7dad6e8080de42a0f6a2110dcb20e35bda8e3a9a47161f26", "0x0207f80673298caa563db3537892881a13d2b234c5e7ab1b5e368ff072542558"),
+		CRn:    types.MustPoint("0x077da99d806abd13c9f15ece5398525119d11e11e9836b2ee7d23f6159ad87d4", "0x01485efa927f2ad41bff567eec88f32fb0a0f706588b4e41a8d587d008b7f875"),
+		Y:      types.MustPoint("0x2af593d93442ca5d86d1f3748e624e68cc7db78da5fa568c40e32753e2e5b64b", "0x301248643b2813c1aaa9fbb7cec25fa6fb8e6d6db1240649b848a545962a9f81"),
+		Epoch:  53672920,
+		Sender: "d80ac1fb177c0b8d9c66de2b9657dd57084a2d7f",
+	}
+	witness := BurnWitness{SK: "0x04907c94209e3442e4830c142ba166ac032e511d00fcdf5f01b77d480518fa1a", BDiff: 99}
+	return statement, witness
+}
+
+func TestUnserializeBurnProof(t *testing.T) {
+	proof, err := ProveBurn(burnVector())
+	assert.NilError(t, err)
+
+	z, err := UnserializeBurnProof(proof)
+	assert.NilError(t, err)
+	assert.Equal(t, z.Serialize(), proof)
+	assert.Equal(t, len(z.GetIPProof().GetL()), 5)
+
+	_, err = UnserializeBurnProof(proof[:len(proof)-64])
+	assert.ErrorContains(t, err, "invalid burn proof length")
+
+	// the last scalar b set to the group order is rejected.
+	q := fmt.Sprintf("%064x", b128.Q().Int)
+	_, err = UnserializeBurnProof(proof[:len(proof)-64] + q)
+	assert.ErrorContains(t, err, "non-canonical scalar")
+}
+
+func BenchmarkProveBurn(b *testing.B) {
+	statement, witness := burnVector()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		ProveBurn(statement, witness)
+	}
+}
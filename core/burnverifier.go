@@ -0,0 +1,130 @@
+package core
+
+import (
+	"encoding/hex"
+	"errors"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/hpb-project/HCash-SDK/common/types"
+	"github.com/hpb-project/HCash-SDK/core/ebigint"
+)
+
+// BurnVerifier is a port of BurnVerifier.sol, it checks a burn proof
+// without sending it to the chain.
+type BurnVerifier struct {
+	params     *GeneratorParams
+	ipVerifier *InnerProductVerifier
+}
+
+func NewBurnVerifier() BurnVerifier {
+	params := GetGeneratorParams(32)
+	return BurnVerifier{
+		params:     params,
+		ipVerifier: new(InnerProductVerifier),
+	}
+}
+
+func (burn BurnVerifier) Verify(istatement BurnStatement, iu types.Point, proofHex string) error {
+	proof, err := UnserializeBurnProof(proofHex)
+	if err != nil {
+		return err
+	}
+	statement, err := BurnProver{}.tointerBurnStatement(istatement)
+	if err != nil {
+		return err
+	}
+	var u = b128.UnSerialize(iu)
+	var statementHash = burnStatementHash(istatement, statement)
+
+	var y, z, zSum, k, t, x *ebigint.NBigInt
+	{
+		arguments := abi.Arguments{
+			{Type: bytes32_T},
+			{Type: bytes32_2T},
+			{Type: bytes32_2T},
+		}
+		bytes, err := arguments.Pack(
+			parseBigInt2ABI_Bytes32(statementHash),
+			parsePoint2ABI_Bytes32_2(proof.BA),
+			parsePoint2ABI_Bytes32_2(proof.BS),
+		)
+		if err != nil {
+			return err
+		}
+		y = Hash(hex.EncodeToString(bytes))
+	}
+	var ys = make([]*ebigint.NBigInt, 32)
+	ys[0] = ebigint.NewNBigInt(1).ToRed(b128.Q())
+	k = ebigint.NewNBigInt(1).ToRed(b128.Q())
+	for i := 1; i < 32; i++ {
+		ys[i] = ys[i-1].RedMul(y)
+		k = k.RedAdd(ys[i])
+	}
+	z = Hash(b128.Bytes(y.Int))
+	var zs = []*ebigint.NBigInt{z.RedExp(big.NewInt(2))}
+	zSum = zs[0].RedMul(z)
+	{
+		two32 := ebigint.NewNBigInt(1 << 32).ToRed(b128.Q())
+		k = k.RedMul(z.RedSub(zs[0])).RedSub(zSum.RedMul(two32).RedSub(zSum))
+	}
+	t = proof.tHat.RedSub(k)
+
+	var twoTimesZSquared = make([]*ebigint.NBigInt, 32)
+	for i := 0; i < 32; i++ {
+		twoTimesZSquared[i] = zs[0].RedMul(ebigint.NewNBigInt(1 << uint(i)).ToRed(b128.Q()))
+	}
+
+	var tCommits = proof.tCommits.GetVector()
+	{
+		arguments := abi.Arguments{
+			{Type: bytes32_T},
+			{Type: bytes32_2T},
+			{Type: bytes32_2T},
+		}
+		bytes, err := arguments.Pack(
+			parseBigInt2ABI_Bytes32(z),
+			parsePoint2ABI_Bytes32_2(tCommits[0]),
+			parsePoint2ABI_Bytes32_2(tCommits[1]),
+		)
+		if err != nil {
+			return err
+		}
+		x = Hash(hex.EncodeToString(bytes))
+	}
+	var tEval = tCommits[0].Mul(x).Add(tCommits[1].Mul(x.RedMul(x)))
+
+	var cNeg = proof.c.RedNeg()
+	var g = burn.params.GetG()
+	var A_y = g.Mul(proof.s_sk).Add(statement.Y.Mul(cNeg))
+	var A_b = g.Mul(proof.s_b).Add(statement.CRn.Mul(proof.s_sk).Add(statement.CLn.Mul(cNeg)).Mul(zs[0]))
+	var A_t = g.Mul(t).Add(tEval.Neg()).Mul(proof.c).Add(burn.params.GetFixedH().Mul(proof.s_tau)).Add(g.Mul(proof.s_b.RedNeg()))
+	var A_u = GEpoch(statement.Epoch).Mul(proof.s_sk).Add(u.Mul(cNeg))
+
+	var c *ebigint.NBigInt
+	{
+		arguments := abi.Arguments{
+			{Type: bytes32_T},
+			{Type: bytes32_2T},
+			{Type: bytes32_2T},
+			{Type: bytes32_2T},
+			{Type: bytes32_2T},
+		}
+		bytes, err := arguments.Pack(
+			parseBigInt2ABI_Bytes32(x),
+			parsePoint2ABI_Bytes32_2(A_y),
+			parsePoint2ABI_Bytes32_2(A_b),
+			parsePoint2ABI_Bytes32_2(A_t),
+			parsePoint2ABI_Bytes32_2(A_u),
+		)
+		if err != nil {
+			return err
+		}
+		c = Hash(hex.EncodeToString(bytes))
+	}
+	if !c.Eq(proof.c) {
+		return errors.New("sigma protocol challenge equality failure")
+	}
+
+	return verifyRangeProof(burn.params, burn.ipVerifier, proof.c, proof.BA, proof.BS, x, z, ys, twoTimesZSquared, proof.mu, proof.tHat, proof.ipProof)
+}
@@ -0,0 +1,88 @@
+package client
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+
+	ethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/hpb-project/HCash-SDK/common/types"
+)
+
+/*
+ * input: {'y':{'gx':'', 'gy':''}, 'prefix':'hc', 'contract':'0x...'}, prefix
+	is hc for the main network when empty, contract is the optional ZSC
+	address the account is registered in.
+ * output: {'address':'hc1...'}
+	Every point of the client parameters also takes an hc address without a
+	contract in place of {'gx':'', 'gy':''}, the others have to go through
+	DecodeAddress so that their network and contract get checked.
+*/
+type EncodeAddressParam struct {
+	Y        types.Point `json:"y"`
+	Prefix   string      `json:"prefix"`
+	Contract string      `json:"contract,omitempty"`
+}
+
+type AddressResponse struct {
+	Address string `json:"address"`
+}
+
+func EncodeAddress(input string) string {
+	var param EncodeAddressParam
+	if e := json.Unmarshal([]byte(input), &param); e != nil {
+		log.Printf("unmarshal param failed, err:%s\n", e.Error())
+		return ""
+	}
+	address, e := encodeAddress(param)
+	if e != nil {
+		log.Printf("encode address failed, err:%s\n", e.Error())
+		return ""
+	}
+	data, _ := json.Marshal(AddressResponse{Address: address})
+	return string(data)
+}
+
+func encodeAddress(param EncodeAddressParam) (string, error) {
+	var a = types.NewAddress(param.Prefix, param.Y)
+	if a.Prefix == "" {
+		a.Prefix = types.MainnetPrefix
+	}
+	if param.Contract != "" {
+		if !ethcommon.IsHexAddress(param.Contract) {
+			return "", errors.New("invalid contract address")
+		}
+		a.Contract = ethcommon.HexToAddress(param.Contract).Bytes()
+	}
+	return types.EncodeAddress(a)
+}
+
+/*
+ * input: {'address':'hc1...'}
+ * output: {'y':{'gx':'', 'gy':''}, 'prefix':'hc', 'contract':'0x...'}, no
+	contract for an address of any contract.
+*/
+type DecodeAddressResponse struct {
+	Y        types.Point `json:"y"`
+	Prefix   string      `json:"prefix"`
+	Contract string      `json:"contract,omitempty"`
+}
+
+func DecodeAddress(input string) string {
+	var param AddressResponse
+	if e := json.Unmarshal([]byte(input), &param); e != nil {
+		log.Printf("unmarshal param failed, err:%s\n", e.Error())
+		return ""
+	}
+	a, e := types.DecodeAddress(param.Address)
+	if e != nil {
+		log.Printf("decode address failed, err:%s\n", e.Error())
+		return ""
+	}
+	var response = DecodeAddressResponse{Y: a.Y, Prefix: a.Prefix}
+	if a.Contract != nil {
+		response.Contract = ethcommon.BytesToAddress(a.Contract).Hex()
+	}
+	data, _ := json.Marshal(response)
+	return string(data)
+}
@@ -0,0 +1,48 @@
+package client
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"gotest.tools/assert"
+)
+
+func TestAddress(t *testing.T) {
+	var y = `{"gx":"0x0456301d6013d1cc52455a37c8762f2463b1c7e148d55e1c7d9980d8ed8d54b8","gy":"0x27e78199776a73737fa833429fd64e00fa592ca21dda2e92d3489c96148308cb"}`
+	var encoded AddressResponse
+	assert.NilError(t, json.Unmarshal([]byte(EncodeAddress(`{"y":`+y+`}`)), &encoded))
+	assert.Assert(t, strings.HasPrefix(encoded.Address, "hc1"), encoded.Address)
+
+	var decoded DecodeAddressResponse
+	assert.NilError(t, json.Unmarshal([]byte(DecodeAddress(`{"address":"`+encoded.Address+`"}`)), &decoded))
+	data, _ := json.Marshal(decoded.Y)
+	assert.Equal(t, string(data), y)
+	assert.Equal(t, decoded.Prefix, "hc")
+	assert.Equal(t, decoded.Contract, "")
+
+	mainnet := encoded.Address
+	bound := EncodeAddress(`{"y":"` + encoded.Address + `","prefix":"thc","contract":"0xE4920905e06c6B6070477c40B85756ffDa3cD3E6"}`)
+	assert.NilError(t, json.Unmarshal([]byte(bound), &encoded))
+	assert.Assert(t, strings.HasPrefix(encoded.Address, "thc1"), encoded.Address)
+	assert.NilError(t, json.Unmarshal([]byte(DecodeAddress(bound)), &decoded))
+	assert.Equal(t, decoded.Contract, "0xE4920905e06c6B6070477c40B85756ffDa3cD3E6")
+
+	// the CR of TestReadBalance given as an address, only a mainnet address
+	// without a contract stands for a point.
+	var params = func(address string) string {
+		return `{
+		"CL": {
+			"gx":"0x1b5d4b9abe488e61bbb92edff41682560a9d6e02335e2bca9b50881c9540e393",
+			"gy":"0x15dc61a9eff5d5a4e70ed97cbce60f7afc69c9925a409ddba365897f1384ca58"
+			},
+		"CR": "` + address + `",
+		"x":  "0x20a89bb465e9e2262e25901525509686f6a26b2fba976f1d9ff00a0cdbb362b0"
+	}`
+	}
+	assert.Equal(t, ReadBalance(params(mainnet)), 2)
+	assert.Equal(t, ReadBalance(params(encoded.Address)), -1)
+
+	assert.Equal(t, DecodeAddress(`{"address":"`+encoded.Address[:len(encoded.Address)-1]+`q"}`), "")
+	assert.Equal(t, EncodeAddress(`{"y":`+y+`,"contract":"0x12"}`), "")
+}
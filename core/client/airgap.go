@@ -0,0 +1,311 @@
+package client
+
+import (
+	crand "crypto/rand"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+
+	ethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/hpb-project/HCash-SDK/common/types"
+	"github.com/hpb-project/HCash-SDK/core"
+)
+
+// Air-gapped proving keeps the Zether key on an offline machine:
+//
+//  1. online, NewProofRequest bundles the ring, the shuffle indexes and the
+//     simulated accounts fetched by the caller, with a checksum.
+//  2. offline, ProveRequest checks the bundle, decrypts the balance with
+//     the key, proves and signs the response with the same key.
+//  3. online, ProofResponseTx checks the response against the request and
+//     returns the transfer or burn calldata.
+const (
+	ProofKindTransfer = "transfer"
+	ProofKindBurn     = "burn"
+)
+
+var (
+	ErrRequestChecksum = errors.New("proof request checksum mismatch")
+	ErrResponseMatch   = errors.New("proof response does not answer the request")
+)
+
+// ProofRequest is everything the offline step needs except the key. For a
+// burn y and accounts hold the single account of the sender and index is
+// empty. Sender is the address sending the transaction, burn proofs are
+// bound to it.
+type ProofRequest struct {
+	Kind     string           `json:"kind"`
+	ZSC      string           `json:"zsc"`
+	Epoch    int              `json:"epoch"`
+	Value    int              `json:"value"`
+	Sender   string           `json:"sender"`
+	Y        []types.Point    `json:"y"`
+	Index    []int            `json:"index,omitempty"`
+	Accounts [][2]types.Point `json:"accounts"`
+	Checksum string           `json:"checksum"`
+}
+
+// digest is keccak256 of the request json without the checksum.
+func (r ProofRequest) digest() string {
+	r.Checksum = ""
+	data, _ := json.Marshal(r)
+	return "0x" + ethcommon.Bytes2Hex(crypto.Keccak256(data))
+}
+
+func (r ProofRequest) check() error {
+	if r.Checksum != r.digest() {
+		return ErrRequestChecksum
+	}
+	if !ethcommon.IsHexAddress(r.ZSC) {
+		return fmt.Errorf("invalid zsc address %q", r.ZSC)
+	}
+	if r.Value < 0 || uint64(r.Value) > uint64(core.B_MAX) {
+		return fmt.Errorf("%w: value %d", core.ErrValueOutOfRange, r.Value)
+	}
+	if len(r.Accounts) != len(r.Y) {
+		return core.ErrRingSizeMismatch
+	}
+	switch r.Kind {
+	case ProofKindTransfer:
+		if len(r.Index) != 2 || r.Index[0] < 0 || r.Index[0] >= len(r.Y) {
+			return core.ErrInvalidIndex
+		}
+	case ProofKindBurn:
+		if len(r.Y) != 1 || len(r.Index) != 0 {
+			return errors.New("burn request must hold exactly the sender account")
+		}
+		if !ethcommon.IsHexAddress(r.Sender) {
+			return fmt.Errorf("invalid sender address %q", r.Sender)
+		}
+	default:
+		return fmt.Errorf("unknown proof kind %q", r.Kind)
+	}
+	return nil
+}
+
+// owner is the index of the account proving in the ring.
+func (r ProofRequest) owner() int {
+	if r.Kind == ProofKindTransfer {
+		return r.Index[0]
+	}
+	return 0
+}
+
+/*
+ * input: the request without checksum, the accounts are the simulated
+	accounts of y at epoch.
+	{'kind':'transfer', 'zsc':'', 'epoch':0, 'value':0, 'sender':'',
+	 'y':[...], 'index':[0, 1], 'accounts':[[CL, CR], ...]}
+ * output: the same request with its 'checksum'.
+*/
+func NewProofRequest(input string) string {
+	var req ProofRequest
+	if e := json.Unmarshal([]byte(input), &req); e != nil {
+		log.Printf("unmarshal to ProofRequest failed, err:%s\n", e.Error())
+		return ""
+	}
+	req.Checksum = req.digest()
+	if e := req.check(); e != nil {
+		log.Printf("invalid proof request, err:%s\n", e.Error())
+		return ""
+	}
+	b, _ := json.Marshal(req)
+	return string(b)
+}
+
+// ProofResponse answers the request with the given checksum, it holds the
+// calldata parameters of exactly one of transfer or burn and is signed by
+// the proving account with a message signature.
+type ProofResponse struct {
+	Kind      string           `json:"kind"`
+	Request   string           `json:"request"`
+	Transfer  *TxTransferParam `json:"transfer,omitempty"`
+	Burn      *TxBurnParam     `json:"burn,omitempty"`
+	Signature MessageSignature `json:"signature"`
+}
+
+// message is what the signature covers, keccak256 of the response json
+// without the signature.
+func (r ProofResponse) message() []byte {
+	r.Signature = MessageSignature{}
+	data, _ := json.Marshal(r)
+	return crypto.Keccak256(data)
+}
+
+/*
+ * input: {'request':{...}, 'account':{'x':'', 'y':{}}, 'workers':0}
+ * output: {'kind':'', 'request':'checksum', 'transfer':{'C':[], 'D':{}, 'u':{}, 'y':[], 'proof':''},
+	'signature':{'y':{}, 'c':'', 's':''}}, with 'burn':{'y':{}, 'value':0, 'u':{}, 'proof':''}
+	in place of 'transfer' for a burn.
+*/
+type ProveRequestParam struct {
+	Request   ProofRequest `json:"request"`
+	Accounter core.Account `json:"account"`
+	Workers   int          `json:"workers,omitempty"`
+}
+
+func ProveRequest(input string) string {
+	return proveRequest(input, crand.Reader)
+}
+
+func proveRequest(input string, random io.Reader) string {
+	var param ProveRequestParam
+	if e := json.Unmarshal([]byte(input), &param); e != nil {
+		log.Printf("unmarshal to ProveRequestParam failed, err:%s\n", e.Error())
+		return ""
+	}
+	res, e := answerRequest(param, random)
+	if e != nil {
+		log.Printf("prove request failed, err:%s\n", e.Error())
+		return ""
+	}
+	b, _ := json.Marshal(res)
+	return string(b)
+}
+
+func answerRequest(param ProveRequestParam, random io.Reader) (*ProofResponse, error) {
+	var req = param.Request
+	if e := req.check(); e != nil {
+		return nil, e
+	}
+	if param.Accounter.X == nil {
+		return nil, fmt.Errorf("%w: missing x", core.ErrInvalidScalar)
+	}
+	var owner = req.owner()
+	if !req.Y[owner].Equal(param.Accounter.Y) {
+		return nil, fmt.Errorf("%w: the request is not for this account", core.ErrKeyMismatch)
+	}
+	var x = param.Accounter.X
+	balance, e := core.ReadBalance(req.Accounts[owner][0], req.Accounts[owner][1], x)
+	if e != nil {
+		return nil, e
+	}
+	if int(balance) < req.Value {
+		return nil, fmt.Errorf("%w: balance %d, value %d", core.ErrInsufficientBalance, balance, req.Value)
+	}
+
+	var res = ProofResponse{Kind: req.Kind, Request: req.Checksum}
+	var sk = b128.Bytes(x.Int)
+	if req.Kind == ProofKindTransfer {
+		res.Transfer, e = proveTransfer(TransferProofParam{
+			Epoch:    req.Epoch,
+			Value:    req.Value,
+			Diff:     int(balance) - req.Value,
+			SK:       sk,
+			Y:        req.Y,
+			Index:    req.Index,
+			Accounts: req.Accounts,
+			Workers:  param.Workers,
+		}, random)
+	} else {
+		res.Burn, e = proveBurn(BurnProofParam{
+			Accounts: req.Accounts[0][:],
+			Epoch:    req.Epoch,
+			Value:    req.Value,
+			Diff:     int(balance) - req.Value,
+			SK:       sk,
+			Y:        req.Y[0],
+			Sender:   req.Sender,
+		}, random)
+	}
+	if e != nil {
+		return nil, e
+	}
+
+	sig, e := signMessage(param.Accounter, "", res.message())
+	if e != nil {
+		return nil, e
+	}
+	res.Signature = *sig
+	return &res, nil
+}
+
+/*
+ * input: {'request':{...}, 'response':{...}}
+ * output: {'to':'zsc address', 'data':'calldata'}
+ */
+type ProofResponseTxParam struct {
+	Request  ProofRequest  `json:"request"`
+	Response ProofResponse `json:"response"`
+}
+
+type ProofResponseTxResult struct {
+	To   string `json:"to"`
+	Data string `json:"data"`
+}
+
+func ProofResponseTx(input string) string {
+	var param ProofResponseTxParam
+	if e := json.Unmarshal([]byte(input), &param); e != nil {
+		log.Printf("unmarshal to ProofResponseTxParam failed, err:%s\n", e.Error())
+		return ""
+	}
+	data, e := responseTx(param.Request, param.Response)
+	if e != nil {
+		log.Printf("invalid proof response, err:%s\n", e.Error())
+		return ""
+	}
+	b, _ := json.Marshal(ProofResponseTxResult{To: param.Request.ZSC, Data: data})
+	return string(b)
+}
+
+// responseTx checks that res answers req, is signed by the proving account
+// and holds a valid proof, then returns the calldata.
+func responseTx(req ProofRequest, res ProofResponse) (string, error) {
+	if e := req.check(); e != nil {
+		return "", e
+	}
+	if res.Kind != req.Kind || res.Request != req.Checksum {
+		return "", ErrResponseMatch
+	}
+	if !res.Signature.Y.Equal(req.Y[req.owner()]) {
+		return "", fmt.Errorf("%w: signed by another account", ErrResponseMatch)
+	}
+	if e := verifyMessage(res.Signature, res.message()); e != nil {
+		return "", e
+	}
+
+	switch {
+	case req.Kind == ProofKindTransfer && res.Transfer != nil && res.Burn == nil:
+		var t = res.Transfer
+		if !pointsEqual(t.Y, req.Y) {
+			return "", fmt.Errorf("%w: ring changed", ErrResponseMatch)
+		}
+		e := verifyTransfer(VerifyTransferParam{
+			Epoch: req.Epoch, Accounts: req.Accounts, C: t.C, D: t.D, U: t.U, Y: t.Y, Proof: t.Proof,
+		})
+		if e != nil {
+			return "", e
+		}
+		return txTransferData(*t), nil
+	case req.Kind == ProofKindBurn && res.Burn != nil && res.Transfer == nil:
+		var b = res.Burn
+		if !b.Y.Equal(req.Y[0]) || b.B != uint64(req.Value) {
+			return "", fmt.Errorf("%w: account or value changed", ErrResponseMatch)
+		}
+		e := verifyBurn(VerifyBurnParam{
+			Epoch: req.Epoch, Accounts: req.Accounts[0][:], Y: b.Y, Value: req.Value,
+			U: b.U, Sender: req.Sender, Proof: b.Proof,
+		})
+		if e != nil {
+			return "", e
+		}
+		return txBurnData(*b), nil
+	}
+	return "", fmt.Errorf("%w: missing %s parameters", ErrResponseMatch, req.Kind)
+}
+
+func pointsEqual(a, b []types.Point) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if !a[i].Equal(b[i]) {
+			return false
+		}
+	}
+	return true
+}
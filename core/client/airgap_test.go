@@ -0,0 +1,127 @@
+package client
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/hpb-project/HCash-SDK/core"
+	"gotest.tools/assert"
+)
+
+const testZSC = "0xE4920905e06c6B6070477c40B85756ffDa3cD3E6"
+
+// runAirgap takes a request without checksum through the three steps.
+func runAirgap(t *testing.T, request string, account string) (ProofRequest, ProofResponse) {
+	var req ProofRequest
+	assert.NilError(t, json.Unmarshal([]byte(NewProofRequest(request)), &req))
+
+	var acc core.Account
+	assert.NilError(t, json.Unmarshal([]byte(account), &acc))
+	param, _ := json.Marshal(ProveRequestParam{Request: req, Accounter: acc})
+	result := proveRequest(string(param), core.NewDRBG([]byte("airgap")))
+	assert.Assert(t, result != "")
+	var res ProofResponse
+	assert.NilError(t, json.Unmarshal([]byte(result), &res))
+	return req, res
+}
+
+func TestAirgapTransfer(t *testing.T) {
+	var request = `{
+		"kind":"transfer", "zsc":"` + testZSC + `", "epoch":53712840, "value":1,
+		"accounts": [
+			[{"gx":"0x053225ab9382466d6b094e6e0ef738df4f3182757c6a0d48ab34f30691c422b5",
+			  "gy":"0x069427041ebfef2669c40b87a9d82690c75d332ecd4c1b775decd0e85884e2af"},
+			 {"gx":"0x08d0fe696c3aff9c574949d3788e3d5379ee4d99ed9014b738f2825b8af231e7",
+			  "gy":"0x061aa65f5f632f30b3eef67207f2c3804f4e5ca07f4aaf195820b37c845fbd16"}],
+			[{"gx":"0x0d9aa6c77eda65eee4299282135136c2b54205ccd835df94dc14bd0eb545553c",
+			  "gy":"0x2ead1bbaa97fa27f76e006f747a08cd8cdb45af4032cbfaffeeb6c1046f0d384"},
+			 {"gx":"0x20bc85cf65b9afe7e4709592e382e59203e99e2c820c8bf2930707687004f687",
+			  "gy":"0x033e5bb2711dae6a5b6f25be4d150bdc43f505863aa1c8eced61ed74c051bee3"}]
+		],
+		"y":[
+			{"gx":"0x2b621590db6b2e3ca3f0e562ed05487caa26ae88c6e1f54883a04e51f6664bc1",
+			 "gy":"0x2c1173b211a55f5397ff869ae2feecad664a80730f4f6236a8664a167577ece7"},
+			{"gx":"0x20710d65688c288d13a36884422807e5f49fb3785023d49067d1f1f1107cb484",
+			 "gy":"0x09ad6933875e421a71f1ed619764ee73b0f628126ca9fe4c153368ed515e6db9"}
+		],
+		"index":[0, 1]
+	}`
+	var account = CreateAccount("0x20a89bb465e9e2262e25901525509686f6a26b2fba976f1d9ff00a0cdbb362b0")
+	req, res := runAirgap(t, request, account)
+	assert.Equal(t, res.Request, req.Checksum)
+	assert.Assert(t, res.Transfer != nil && res.Burn == nil)
+
+	data, err := responseTx(req, res)
+	assert.NilError(t, err)
+	assert.Equal(t, data, txTransferData(*res.Transfer))
+
+	param, _ := json.Marshal(ProofResponseTxParam{Request: req, Response: res})
+	var tx ProofResponseTxResult
+	assert.NilError(t, json.Unmarshal([]byte(ProofResponseTx(string(param))), &tx))
+	assert.Equal(t, tx.To, testZSC)
+	assert.Equal(t, tx.Data, data)
+
+	// a request changed after the checksum.
+	changed := req
+	changed.Value = 2
+	_, err = responseTx(changed, res)
+	assert.Assert(t, errors.Is(err, ErrRequestChecksum))
+
+	// a response changed after the signature.
+	tampered := res
+	transfer := *res.Transfer
+	transfer.C = append(transfer.C[1:], transfer.C[0])
+	tampered.Transfer = &transfer
+	_, err = responseTx(req, tampered)
+	assert.Assert(t, errors.Is(err, core.ErrInvalidSignature))
+
+	// the key of another account can not answer the request.
+	var other core.Account
+	assert.NilError(t, json.Unmarshal([]byte(CreateAccount("0x05")), &other))
+	_, err = answerRequest(ProveRequestParam{Request: req, Accounter: other}, core.NewDRBG(nil))
+	assert.Assert(t, errors.Is(err, core.ErrKeyMismatch))
+}
+
+func TestAirgapBurn(t *testing.T) {
+	var request = `{
+		"kind":"burn", "zsc":"` + testZSC + `", "epoch":53672920, "value":1,
+		"sender":"0xd80ac1fb177c0b8d9c66de2b9657dd57084a2d7f",
+		"accounts":[[
+			{"gx":"0x19512743220081b7244cae299bb9f053b25d27337ee6b5d760eae272117db2af",
+			 "gy":"0x0f7dae3691a53ec20f37e534c34c0eb41d256c8c7f9472e4c618126d6a054b58"},
+			{"gx":"0x077da99d806abd13c9f15ece5398525119d11e11e9836b2ee7d23f6159ad87d4",
+			 "gy":"0x01485efa927f2ad41bff567eec88f32fb0a0f706588b4e41a8d587d008b7f875"}
+		]],
+		"y":[{
+			"gx":"0x2af593d93442ca5d86d1f3748e624e68cc7db78da5fa568c40e32753e2e5b64b",
+			"gy":"0x301248643b2813c1aaa9fbb7cec25fa6fb8e6d6db1240649b848a545962a9f81"
+		}]
+	}`
+	var account = CreateAccount("0x04907c94209e3442e4830c142ba166ac032e511d00fcdf5f01b77d480518fa1a")
+	req, res := runAirgap(t, request, account)
+	assert.Assert(t, res.Burn != nil && res.Transfer == nil)
+	assert.Equal(t, res.Burn.B, uint64(1))
+
+	data, err := responseTx(req, res)
+	assert.NilError(t, err)
+	assert.Equal(t, data, txBurnData(*res.Burn))
+
+	// the response can not be moved to another request.
+	other := req
+	other.Sender = "0xe4920905e06c6b6070477c40b85756ffda3cd3e6"
+	other.Checksum = other.digest()
+	res.Request = other.Checksum
+	_, err = responseTx(other, res)
+	assert.Assert(t, errors.Is(err, core.ErrInvalidSignature))
+
+	// more than the balance of 100.
+	var req2 ProofRequest
+	assert.NilError(t, json.Unmarshal([]byte(request), &req2))
+	req2.Value = 101
+	req2.Checksum = req2.digest()
+	var acc core.Account
+	assert.NilError(t, json.Unmarshal([]byte(account), &acc))
+	_, err = answerRequest(ProveRequestParam{Request: req2, Accounter: acc}, core.NewDRBG(nil))
+	assert.Assert(t, errors.Is(err, core.ErrInsufficientBalance))
+}
@@ -1,11 +1,16 @@
 package client
 
 import (
+	crand "crypto/rand"
 	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
 	"log"
 	"math"
 	"math/big"
 	"math/rand"
+	"time"
 
 	"github.com/hpb-project/HCash-SDK/common"
 	"github.com/hpb-project/HCash-SDK/common/types"
@@ -22,13 +27,21 @@ var (
  * output: {'x':'', 'y':{'gx':'', 'gy':''}}
  */
 func CreateAccount(secret string) string {
+	return createAccount(secret, crand.Reader)
+}
+
+func createAccount(secret string, random io.Reader) string {
 	var account core.Account
 	if secret != "" {
 		x, _ := new(big.Int).SetString(common.HexWithout0x(secret), 16)
 		account.X = ebigint.ToNBigInt(x).ToRed(b128.Q())
-		account.Y = b128.Serialize(b128.CurveG().Mul(account.X))
+		account.Y = b128.Serialize(core.FixedG().Mul(account.X))
 	} else {
-		account = core.CreateAccount()
+		var e error
+		if account, e = core.CreateAccountWithReader(random); e != nil {
+			log.Printf("create account failed, err:%s\n", e.Error())
+			return ""
+		}
 	}
 
 	data, _ := json.Marshal(account)
@@ -39,6 +52,8 @@ func CreateAccount(secret string) string {
  * input:
 	zscAddress : zsc contract address string,
 	account    : account json string. {'x':'', 'y': {'gx':'',  'gy':''}}
+	random     : optional nonce k, only for reproducing old signatures.
+	keySocket  : optional key process, it replaces account and random.
  * output:
 	json string, content is big number hex string. {'c':'', 's':''}
 */
@@ -46,6 +61,7 @@ type SignParam struct {
 	ZSCAddr   string       `json:"address"`
 	Accounter core.Account `json:"account"`
 	Random    string       `json:"random"`
+	KeySocket string       `json:"keySocket,omitempty"`
 }
 
 func Sign(input string) string {
@@ -56,14 +72,20 @@ func Sign(input string) string {
 		log.Printf("unmarshal param failed, err:%s\n", e.Error())
 		return ""
 	}
-	if param.Random != "" {
-		nk, ok := new(big.Int).SetString(common.HexWithout0x(param.Random), 16)
-		if !ok {
-			c, s, e = core.Sign(common.FromHex(param.ZSCAddr), param.Accounter)
-		} else {
-			sign_k := ebigint.ToNBigInt(nk)
-			c, s, e = core.SignWithRandom(common.FromHex(param.ZSCAddr), param.Accounter, sign_k)
-		}
+	socket, e := dialKey(param.KeySocket)
+	if e != nil {
+		log.Println("sign failed error:", e.Error())
+		return ""
+	}
+	// random is only kept to reproduce old signatures, without it the nonce
+	// is derived from x, address and y.
+	nk, ok := new(big.Int).SetString(common.HexWithout0x(param.Random), 16)
+	if socket != nil {
+		defer socket.Close()
+		c, s, e = socket.Sign(common.FromHex(param.ZSCAddr))
+	} else if param.Random != "" && ok {
+		sign_k := ebigint.ToNBigInt(nk)
+		c, s, e = core.SignWithRandom(common.FromHex(param.ZSCAddr), param.Accounter, sign_k)
 	} else {
 		c, s, e = core.Sign(common.FromHex(param.ZSCAddr), param.Accounter)
 	}
@@ -85,24 +107,109 @@ func Sign(input string) string {
 	return string(data)
 }
 
+/*
+ * input: the register signature of y for the zsc contract address.
+	{'address':'', 'y':{'gx':'', 'gy':''}, 'c':'', 's':''}
+ * output: {'valid':true} or {'valid':false, 'reason':''}
+*/
+type VerifySignParam struct {
+	ZSCAddr string      `json:"address"`
+	Y       types.Point `json:"y"`
+	C       string      `json:"c"`
+	S       string      `json:"s"`
+}
+
+func VerifySign(input string) string {
+	var param VerifySignParam
+	if e := json.Unmarshal([]byte(input), &param); e != nil {
+		log.Printf("unmarshal param failed, err:%s\n", e.Error())
+		return ""
+	}
+	c, okc := new(big.Int).SetString(common.HexWithout0x(param.C), 16)
+	s, oks := new(big.Int).SetString(common.HexWithout0x(param.S), 16)
+	if !okc || !oks {
+		return verifyResult(core.ErrInvalidSignature)
+	}
+	return verifyResult(core.VerifySign(common.FromHex(param.ZSCAddr), param.Y, ebigint.ToNBigInt(c), ebigint.ToNBigInt(s)))
+}
+
 /*
  * input: param is json string, {''}
  */
 type ReadBalanceParam struct {
-	CL types.Point `json:"CL"`
-	CR types.Point `json:"CR"`
-	X  string      `json:"x"`
+	CL        types.Point `json:"CL"`
+	CR        types.Point `json:"CR"`
+	X         string      `json:"x"`
+	KeySocket string      `json:"keySocket,omitempty"`
 }
 
+// ReadBalance returns the decrypted balance, or -1 when the input is invalid
+// or the balance can not be found.
 func ReadBalance(param string) int {
 	var p ReadBalanceParam
 	if e := json.Unmarshal([]byte(param), &p); e != nil {
 		log.Printf("unmarshal param failed, err:%s\n", e.Error())
-		return 0
+		return -1
+	}
+	key, err := dialKey(p.KeySocket)
+	if err != nil {
+		log.Printf("dial key failed, err:%s\n", err.Error())
+		return -1
+	}
+	var balance uint32
+	if key != nil {
+		defer key.Close()
+		balance, err = core.ReadBalanceWithKey(p.CL, p.CR, key)
+	} else {
+		balance, err = core.ReadBalance(p.CL, p.CR, ebigint.FromHex(p.X).ForceRed(b128.Q()))
+	}
+	if err != nil {
+		log.Printf("read balance failed, err:%s\n", err.Error())
+		return -1
+	}
+	return int(balance)
+}
+
+/*
+ * input: {'accounts':[[CL, CR], ...], 'x':''}
+ * output: {'balances':[b0, ...]}
+ */
+type ReadBalancesParam struct {
+	Accounts [][2]types.Point `json:"accounts"`
+	X        string           `json:"x"`
+}
+
+type ReadBalancesResponse struct {
+	Balances []uint32 `json:"balances"`
+}
+
+func ReadBalances(param string) string {
+	var p ReadBalancesParam
+	if e := json.Unmarshal([]byte(param), &p); e != nil {
+		log.Printf("unmarshal param failed, err:%s\n", e.Error())
+		return ""
 	}
 	x := ebigint.FromHex(p.X).ForceRed(b128.Q())
 
-	return core.ReadBalance(p.CL, p.CR, x)
+	balances, err := core.DefaultBalanceTable().ReadBalances(p.Accounts, x)
+	if err != nil {
+		log.Printf("read balances failed, err:%s\n", err.Error())
+		return ""
+	}
+	b, _ := json.Marshal(ReadBalancesResponse{Balances: balances})
+	return string(b)
+}
+
+// LoadBalanceTable replaces the default decryption table with one saved by
+// core.BalanceTable.SaveFile, it returns 0 on success and -1 on failure.
+func LoadBalanceTable(path string) int {
+	t, err := core.LoadBalanceTableFile(path)
+	if err != nil {
+		log.Printf("load balance table failed, err:%s\n", err.Error())
+		return -1
+	}
+	core.SetDefaultBalanceTable(t)
+	return 0
 }
 
 /*
@@ -188,27 +295,68 @@ type TransferProofParam struct {
 	Y        []types.Point    `json:"y"`
 	Index    []int            `json:"index"`
 	Accounts [][2]types.Point `json:"accounts"`
+	Workers  int              `json:"workers,omitempty"`
+	// KeySocket is the unix socket of a key process, it replaces SK.
+	KeySocket string `json:"keySocket,omitempty"`
+	// Invoice is checked before proving, see VerifyInvoice.
+	Invoice *VerifyInvoiceParam `json:"invoice,omitempty"`
 }
 
 func TransferProof(param string) string {
+	return transferProof(param, crand.Reader)
+}
+
+func transferProof(param string, random io.Reader) string {
 	var p TransferProofParam
 	if e := json.Unmarshal([]byte(param), &p); e != nil {
 		log.Printf("unmarshal param to TransferProofParam failed, err:%s\n", e.Error())
 		return ""
 	}
+	res, e := proveTransfer(p, random)
+	if e != nil {
+		log.Printf("transfer proof failed, err:%s\n", e.Error())
+		return ""
+	}
+	b, _ := json.Marshal(res)
+	return string(b)
+}
+
+// proveTransfer builds the statement from the simulated accounts and proves
+// it, the result is the input of TxTransfer.
+func proveTransfer(p TransferProofParam, random io.Reader) (*TxTransferParam, error) {
+	if len(p.Accounts) != len(p.Y) {
+		return nil, core.ErrRingSizeMismatch
+	}
+	if len(p.Index) != 2 {
+		return nil, core.ErrInvalidIndex
+	}
+	if p.Invoice != nil {
+		if p.Index[1] < 0 || p.Index[1] >= len(p.Y) {
+			return nil, core.ErrInvalidIndex
+		}
+		if e := checkInvoice(*p.Invoice, p.Y[p.Index[1]], p.Value, time.Now()); e != nil {
+			return nil, e
+		}
+	}
 	var unserialized = make([][2]core.Point, 0)
-	for _, account := range p.Accounts {
+	for i, account := range p.Accounts {
 		var m [2]core.Point
-		m[0] = b128.UnSerialize(account[0])
-		m[1] = b128.UnSerialize(account[1])
+		var e error
+		for j := range m {
+			if m[j], e = b128.DecodePoint(account[j]); e != nil {
+				return nil, fmt.Errorf("account %d: %w", i, e)
+			}
+		}
 		unserialized = append(unserialized, m)
 	}
 	if Some(unserialized) {
-		log.Printf("Reject, please make sure all parties(include decoys) are registered\n")
-		return ""
+		return nil, errors.New("reject, please make sure all parties(include decoys) are registered")
 	}
 
-	var r = b128.RandomScalar()
+	r, e := core.RandomScalarFrom(random)
+	if e != nil {
+		return nil, fmt.Errorf("read random failed, err:%s", e.Error())
+	}
 
 	var C = make([]core.Point, len(p.Y))
 	for i, party := range p.Y {
@@ -227,10 +375,14 @@ func TransferProof(param string) string {
 				temp = ebigint.NewNBigInt(0).ForceRed(b128.Q())
 			}
 		}
-		t1 := b128.UnSerialize(party).Mul(r)
-		C[i] = b128.CurveG().Mul(temp).Add(t1)
+		y, e := b128.DecodePoint(party)
+		if e != nil {
+			return nil, fmt.Errorf("y %d: %w", i, e)
+		}
+		t1 := y.Mul(r)
+		C[i] = core.FixedG().Mul(temp).Add(t1)
 	}
-	var D = b128.CurveG().Mul(r)
+	var D = core.FixedG().Mul(r)
 	var CLn = make([]types.Point, len(unserialized))
 	var CRn = make([]types.Point, len(unserialized))
 	for i, account := range unserialized {
@@ -258,27 +410,30 @@ func TransferProof(param string) string {
 	witness.BTransfer = p.Value
 	witness.R = r.Text(16)
 	witness.SK = p.SK
-	var proof = core.ProveTransfer(statement, witness)
-
-	sk := ebigint.FromHex(p.SK)
-	var u = b128.Serialize(core.U(p.Epoch, sk))
-
-	type Response struct {
-		C     []types.Point `json:"C"`
-		D     types.Point   `json:"D"`
-		U     types.Point   `json:"u"`
-		Y     []types.Point `json:"y"`
-		Proof string        `json:"proof"`
+	key, e := dialKey(p.KeySocket)
+	if e != nil {
+		return nil, e
+	}
+	if key != nil {
+		defer key.Close()
+		witness.Key = key
 	}
-	var res Response
+	proof, e := core.ProveTransferWithReader(statement, witness, p.Workers, random)
+	if e != nil {
+		return nil, e
+	}
+	u, e := proofNonce(key, p.SK, p.Epoch)
+	if e != nil {
+		return nil, e
+	}
+
+	var res TxTransferParam
 	res.C = NC
 	res.D = ND
 	res.U = u
 	res.Y = p.Y
 	res.Proof = proof
-
-	b, _ := json.Marshal(res)
-	return string(b)
+	return &res, nil
 }
 
 func Some(accounts [][2]core.Point) bool {
@@ -302,16 +457,45 @@ type BurnProofParam struct {
 	SK       string        `json:"sk"`
 	Y        types.Point   `json:"y"`
 	Sender   string        `json:"sender"`
+	// KeySocket is the unix socket of a key process, it replaces SK.
+	KeySocket string `json:"keySocket,omitempty"`
 }
 
 func BurnProof(param string) string {
+	return burnProof(param, crand.Reader)
+}
+
+func burnProof(param string, random io.Reader) string {
 	var p BurnProofParam
 	if e := json.Unmarshal([]byte(param), &p); e != nil {
 		log.Printf("unmarshal to BurnProofParam failed, err:%s\n", e.Error())
 		return ""
 	}
+	res, e := proveBurn(p, random)
+	if e != nil {
+		log.Printf("burn proof failed, err:%s\n", e.Error())
+		return ""
+	}
+	type Response struct {
+		U     types.Point `json:"u"`
+		Proof string      `json:"proof"`
+	}
+	b, _ := json.Marshal(Response{U: res.U, Proof: res.Proof})
+	return string(b)
+}
+
+// proveBurn proves the burn of p.Value from the simulated account of y,
+// the result is the input of TxBurn.
+func proveBurn(p BurnProofParam, random io.Reader) (*TxBurnParam, error) {
 	var simulated = p.Accounts
-	var CLn = b128.Serialize(b128.UnSerialize(simulated[0]).Add(b128.CurveG().Mul(ebigint.NewNBigInt(-int64(p.Value)))))
+	if len(simulated) != 2 {
+		return nil, fmt.Errorf("want [CL, CR], got %d points", len(simulated))
+	}
+	CL, e := b128.DecodePoint(simulated[0])
+	if e != nil {
+		return nil, fmt.Errorf("CL: %w", e)
+	}
+	var CLn = b128.Serialize(CL.Add(core.FixedG().Mul(ebigint.NewNBigInt(-int64(p.Value)))))
 	var CRn = simulated[1]
 	var statement core.BurnStatement
 	statement.Y = p.Y
@@ -323,20 +507,29 @@ func BurnProof(param string) string {
 	var witness core.BurnWitness
 	witness.SK = p.SK
 	witness.BDiff = p.Diff
-	var proof = core.ProveBurn(statement, witness)
-	sk := ebigint.FromBytes(common.FromHex(p.SK))
-	var u = b128.Serialize(core.U(p.Epoch, sk))
-
-	type Response struct {
-		U     types.Point `json:"u"`
-		Proof string      `json:"proof"`
+	key, e := dialKey(p.KeySocket)
+	if e != nil {
+		return nil, e
+	}
+	if key != nil {
+		defer key.Close()
+		witness.Key = key
 	}
-	var res Response
+	proof, e := core.ProveBurnWithReader(statement, witness, random)
+	if e != nil {
+		return nil, e
+	}
+	u, e := proofNonce(key, p.SK, p.Epoch)
+	if e != nil {
+		return nil, e
+	}
+
+	var res TxBurnParam
+	res.Y = p.Y
+	res.B = uint64(p.Value)
 	res.U = u
 	res.Proof = proof
-
-	b, _ := json.Marshal(res)
-	return string(b)
+	return &res, nil
 }
 
 type APIResponse struct {
@@ -395,6 +588,13 @@ func TxTransfer(param string) string {
 		return ""
 	}
 	var res APIResponse
+	res.Data = txTransferData(p)
+
+	b, _ := json.Marshal(res)
+	return string(b)
+}
+
+func txTransferData(p TxTransferParam) string {
 	var y = "0x"
 	var c = "0x"
 
@@ -404,10 +604,7 @@ func TxTransfer(param string) string {
 	for _, xy := range p.C {
 		c += xy.XY()[2:]
 	}
-	res.Data = "0x" + core.Transfer(c, p.D.XY(), y, p.U.XY(), p.Proof)
-
-	b, _ := json.Marshal(res)
-	return string(b)
+	return "0x" + core.Transfer(c, p.D.XY(), y, p.U.XY(), p.Proof)
 }
 
 type TxBurnParam struct {
@@ -424,12 +621,16 @@ func TxBurn(param string) string {
 		return ""
 	}
 	var res APIResponse
-	res.Data = "0x" + core.Burn(p.Y.XY(), p.B, p.U.XY(), p.Proof)
+	res.Data = txBurnData(p)
 
 	b, _ := json.Marshal(res)
 	return string(b)
 }
 
+func txBurnData(p TxBurnParam) string {
+	return "0x" + core.Burn(p.Y.XY(), p.B, p.U.XY(), p.Proof)
+}
+
 type TxSimulateAccountsParam struct {
 	Y     []types.Point `json:"y"`
 	Epoch uint64        `json:"epoch"`
@@ -471,3 +672,107 @@ func ParseSimulateAccountsData(param string) string {
 	b, _ := json.Marshal(res)
 	return string(b)
 }
+
+/*
+ * input: the simulated accounts of y at epoch plus the transfer calldata.
+	{'epoch':0, 'accounts':[[{'gx':'', 'gy':''}, {'gx':'', 'gy':''}],...],
+	 'C':[...], 'D':{}, 'u':{}, 'y':[...], 'proof':''}
+ * output: {'valid':true} or {'valid':false, 'reason':''}
+*/
+type VerifyTransferParam struct {
+	Epoch    int              `json:"epoch"`
+	Accounts [][2]types.Point `json:"accounts"`
+	C        []types.Point    `json:"C"`
+	D        types.Point      `json:"D"`
+	U        types.Point      `json:"u"`
+	Y        []types.Point    `json:"y"`
+	Proof    string           `json:"proof"`
+}
+
+type VerifyResponse struct {
+	Valid  bool   `json:"valid"`
+	Reason string `json:"reason,omitempty"`
+}
+
+func verifyResult(err error) string {
+	var res VerifyResponse
+	if err != nil {
+		res.Reason = err.Error()
+	} else {
+		res.Valid = true
+	}
+	b, _ := json.Marshal(res)
+	return string(b)
+}
+
+func VerifyTransfer(param string) string {
+	var p VerifyTransferParam
+	if e := json.Unmarshal([]byte(param), &p); e != nil {
+		log.Printf("unmarshal to VerifyTransferParam failed, err:%s\n", e.Error())
+		return ""
+	}
+	return verifyResult(verifyTransfer(p))
+}
+
+func verifyTransfer(p VerifyTransferParam) error {
+	if len(p.Accounts) != len(p.C) || len(p.Accounts) != len(p.Y) {
+		return errors.New("input array length mismatch")
+	}
+	// the same CLn/CRn the contract rolls over before calling the verifier.
+	var CLn = make([]types.Point, len(p.Accounts))
+	var CRn = make([]types.Point, len(p.Accounts))
+	var D = b128.UnSerialize(p.D)
+	for i, account := range p.Accounts {
+		CLn[i] = b128.Serialize(b128.UnSerialize(account[0]).Add(b128.UnSerialize(p.C[i])))
+		CRn[i] = b128.Serialize(b128.UnSerialize(account[1]).Add(D))
+	}
+
+	var statement core.TransferStatement
+	statement.Epoch = p.Epoch
+	statement.Y = p.Y
+	statement.D = p.D
+	statement.C = p.C
+	statement.CLn = CLn
+	statement.CRn = CRn
+
+	return core.VerifyTransfer(statement, p.U, p.Proof)
+}
+
+/*
+ * input: the simulated account of y at epoch plus the burn calldata and sender.
+	{'epoch':0, 'accounts':[{'gx':'', 'gy':''}, {'gx':'', 'gy':''}],
+	 'y':{}, 'value':0, 'u':{}, 'sender':'', 'proof':''}
+ * output: {'valid':true} or {'valid':false, 'reason':''}
+*/
+type VerifyBurnParam struct {
+	Epoch    int           `json:"epoch"`
+	Accounts []types.Point `json:"accounts"`
+	Y        types.Point   `json:"y"`
+	Value    int           `json:"value"`
+	U        types.Point   `json:"u"`
+	Sender   string        `json:"sender"`
+	Proof    string        `json:"proof"`
+}
+
+func VerifyBurn(param string) string {
+	var p VerifyBurnParam
+	if e := json.Unmarshal([]byte(param), &p); e != nil {
+		log.Printf("unmarshal to VerifyBurnParam failed, err:%s\n", e.Error())
+		return ""
+	}
+	return verifyResult(verifyBurn(p))
+}
+
+func verifyBurn(p VerifyBurnParam) error {
+	if len(p.Accounts) != 2 {
+		return errors.New("accounts must be the (CL, CR) pair of y")
+	}
+	var statement core.BurnStatement
+	statement.CLn = b128.Serialize(b128.UnSerialize(p.Accounts[0]).Add(core.FixedG().Mul(ebigint.NewNBigInt(-int64(p.Value)))))
+	statement.CRn = p.Accounts[1]
+	statement.Y = p.Y
+	statement.Epoch = p.Epoch
+	statement.Sender = p.Sender
+
+	return core.VerifyBurn(statement, p.U, p.Proof)
+}
@@ -1,22 +1,36 @@
 package client
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
 	"github.com/hpb-project/HCash-SDK/common/types"
+	"github.com/hpb-project/HCash-SDK/core"
 	"github.com/hpb-project/HCash-SDK/core/ebigint"
 	"gotest.tools/assert"
+	"io"
 	"log"
 	"strings"
 	"testing"
 )
 
+// testRandom makes every blinding scalar equal to the one the fixed proof
+// vectors were generated with.
+func testRandom() io.Reader {
+	var b = make([]byte, 32)
+	ebigint.FromHex("c3f4db6cd90e04d6e086f73fdb7a4ccaa4f57e48593d80c11c0fdd1fcac348df").ToRed(b128.Q()).FillBytes(b)
+	return bytes.NewReader(bytes.Repeat(b, 1024))
+}
+
 func TestCreateAccount(t *testing.T) {
 
 	for i := 0; i < 5; i++ {
 		account := CreateAccount("")
 		fmt.Println("create random account", account)
 	}
+	seeded := createAccount("", core.NewDRBG([]byte("account")))
+	assert.Assert(t, seeded != "")
+	assert.Equal(t, createAccount("", core.NewDRBG([]byte("account"))), seeded)
 
 	specialAccount := CreateAccount("0x299569ae0ae1d40140fd8d9afc54d2f581a292fd13fe88c7033d488119bb95b7")
 	fmt.Println("specialAccount=", specialAccount)
@@ -26,7 +40,7 @@ func TestCreateAccount(t *testing.T) {
 	}
 	type Acc struct {
 		X string `json:"x"`
-		Y Accy   `json"y"`
+		Y Accy   `json:"y"`
 	}
 	var acc Acc
 	if err := json.Unmarshal([]byte(specialAccount), &acc); err != nil {
@@ -79,6 +93,37 @@ func TestSign(t *testing.T) {
 	assert.Equal(t, sr.S, "0x003fe7000561eeebccd4bff3160cd7f8fd50db62904d8fa217692a1f6ca8e7ed")
 }
 
+func TestVerifySign(t *testing.T) {
+	var account = `{
+			"x": "0x299569ae0ae1d40140fd8d9afc54d2f581a292fd13fe88c7033d488119bb95b7",
+			"y": {
+				"gx":"0x042526b090bc34791599c53df82a129307914728eb9dcafe4a56d66d6c7cc76f",
+				"gy":"0x09c7fcbde6288f52f715f460f495714606b1e11897d1cff6fd80c576f6b9a896"
+			}
+		}`
+	result := Sign(`{"address":"0xE4920905e06c6B6070477c40B85756ffDa3cD3E6", "account":` + account + `}`)
+	assert.Assert(t, result != "")
+	assert.Equal(t, Sign(`{"address":"0xE4920905e06c6B6070477c40B85756ffDa3cD3E6", "account":`+account+`}`), result)
+
+	var sr struct {
+		C string `json:"c"`
+		S string `json:"s"`
+	}
+	assert.NilError(t, json.Unmarshal([]byte(result), &sr))
+	var param = VerifySignParam{
+		ZSCAddr: "0xE4920905e06c6B6070477c40B85756ffDa3cD3E6",
+		Y:       types.MustPoint("0x042526b090bc34791599c53df82a129307914728eb9dcafe4a56d66d6c7cc76f", "0x09c7fcbde6288f52f715f460f495714606b1e11897d1cff6fd80c576f6b9a896"),
+		C:       sr.C,
+		S:       sr.S,
+	}
+	data, _ := json.Marshal(param)
+	assert.Equal(t, VerifySign(string(data)), `{"valid":true}`)
+
+	param.ZSCAddr = "0xd80ac1fb177c0b8d9c66de2b9657dd57084a2d7f"
+	data, _ = json.Marshal(param)
+	assert.Equal(t, VerifySign(string(data)), `{"valid":false,"reason":"invalid signature"}`)
+}
+
 func TestTransferProof(t *testing.T) {
 	var params = `{
 		"epoch":53712840,
@@ -105,8 +150,9 @@ func TestTransferProof(t *testing.T) {
 		],
 		"index":[0, 1]
 	}`
-	b128.SetSpecialRandom(ebigint.FromHex("c3f4db6cd90e04d6e086f73fdb7a4ccaa4f57e48593d80c11c0fdd1fcac348df").ToRed(b128.Q()))
-	result := TransferProof(params)
+	result := transferProof(params, core.NewDRBG([]byte("transfer")))
+	assert.Assert(t, result != "")
+	assert.Equal(t, transferProof(params, core.NewDRBG([]byte("transfer"))), result)
 	log.Println("result = ", result)
 }
 
@@ -149,8 +195,7 @@ func TestBurnProof(t *testing.T) {
 		},
 		"sender":"0xd80ac1fb177c0b8d9c66de2b9657dd57084a2d7f"
 	}`
-	b128.SetSpecialRandom(ebigint.FromHex("c3f4db6cd90e04d6e086f73fdb7a4ccaa4f57e48593d80c11c0fdd1fcac348df").ToRed(b128.Q()))
-	result := BurnProof(params)
+	result := burnProof(params, testRandom())
 	type Response struct {
 		U     types.Point `json:"u"`
 		Proof string      `json:"proof"`
@@ -270,3 +315,50 @@ func TestParseSimulateAccountsData(t *testing.T) {
 		]}
 	*/
 }
+
+func TestVerifyBurn(t *testing.T) {
+	var params = `{
+		"accounts":[
+			{"gx":"0x19512743220081b7244cae299bb9f053b25d27337ee6b5d760eae272117db2af",
+			 "gy":"0x0f7dae3691a53ec20f37e534c34c0eb41d256c8c7f9472e4c618126d6a054b58"},
+			{"gx":"0x077da99d806abd13c9f15ece5398525119d11e11e9836b2ee7d23f6159ad87d4",
+			  "gy":"0x01485efa927f2ad41bff567eec88f32fb0a0f706588b4e41a8d587d008b7f875"}
+		],
+		"epoch":53672920,
+		"value":1,
+		"diff":99,
+		"sk":"0x04907c94209e3442e4830c142ba166ac032e511d00fcdf5f01b77d480518fa1a",
+		"y":{
+			"gx":"0x2af593d93442ca5d86d1f3748e624e68cc7db78da5fa568c40e32753e2e5b64b",
+			"gy":"0x301248643b2813c1aaa9fbb7cec25fa6fb8e6d6db1240649b848a545962a9f81"
+		},
+		"sender":"0xd80ac1fb177c0b8d9c66de2b9657dd57084a2d7f"
+	}`
+	var bp BurnProofParam
+	assert.NilError(t, json.Unmarshal([]byte(params), &bp))
+	type Response struct {
+		U     types.Point `json:"u"`
+		Proof string      `json:"proof"`
+	}
+	var proof Response
+	assert.NilError(t, json.Unmarshal([]byte(BurnProof(params)), &proof))
+
+	var vp = VerifyBurnParam{
+		Epoch:    bp.Epoch,
+		Accounts: bp.Accounts,
+		Y:        bp.Y,
+		Value:    bp.Value,
+		U:        proof.U,
+		Sender:   bp.Sender,
+		Proof:    proof.Proof,
+	}
+	var res VerifyResponse
+	b, _ := json.Marshal(vp)
+	assert.NilError(t, json.Unmarshal([]byte(VerifyBurn(string(b))), &res))
+	assert.Assert(t, res.Valid, res.Reason)
+
+	vp.Value = 2
+	b, _ = json.Marshal(vp)
+	assert.NilError(t, json.Unmarshal([]byte(VerifyBurn(string(b))), &res))
+	assert.Assert(t, !res.Valid)
+}
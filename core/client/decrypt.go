@@ -0,0 +1,67 @@
+package client
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+
+	"github.com/hpb-project/HCash-SDK/common/types"
+	"github.com/hpb-project/HCash-SDK/core"
+	"github.com/hpb-project/HCash-SDK/core/ebigint"
+)
+
+/*
+ * input: {'calldata':'0x...', 'x':''}, the input of the ZSC.transfer
+	transaction, or {'C':[], 'D':{}, 'y':[], 'x':''} as given to TxTransfer.
+	keySocket replaces x.
+ * output: {'index':0, 'role':'sender'|'receiver'|'decoy', 'value':0}, value is
+	the amount received, or debited for the sender.
+*/
+type DecryptTransferParam struct {
+	Calldata  string        `json:"calldata,omitempty"`
+	C         []types.Point `json:"C,omitempty"`
+	D         types.Point   `json:"D"`
+	Y         []types.Point `json:"y,omitempty"`
+	X         string        `json:"x"`
+	KeySocket string        `json:"keySocket,omitempty"`
+}
+
+func DecryptTransfer(input string) string {
+	var param DecryptTransferParam
+	if e := json.Unmarshal([]byte(input), &param); e != nil {
+		log.Printf("unmarshal param failed, err:%s\n", e.Error())
+		return ""
+	}
+	res, e := decryptTransfer(param)
+	if e != nil {
+		log.Printf("decrypt transfer failed, err:%s\n", e.Error())
+		return ""
+	}
+	data, _ := json.Marshal(res)
+	return string(data)
+}
+
+func decryptTransfer(param DecryptTransferParam) (*core.DecryptedTransfer, error) {
+	if param.Calldata != "" {
+		calldata, e := core.ParseTransfer(param.Calldata)
+		if e != nil {
+			return nil, e
+		}
+		param.C, param.D, param.Y = calldata.C, calldata.D, calldata.Y
+	}
+	var key core.KeyHandle
+	socket, e := dialKey(param.KeySocket)
+	if e != nil {
+		return nil, e
+	}
+	if socket != nil {
+		defer socket.Close()
+		key = socket
+	} else {
+		if param.X == "" {
+			return nil, errors.New("x or keySocket is required")
+		}
+		key = core.NewMemoryKey(ebigint.FromHex(param.X).ForceRed(b128.Q()))
+	}
+	return core.DecryptTransfer(param.C, param.D, param.Y, key)
+}
@@ -0,0 +1,55 @@
+package client
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/hpb-project/HCash-SDK/common/types"
+	"github.com/hpb-project/HCash-SDK/core"
+	"github.com/hpb-project/HCash-SDK/core/ebigint"
+	"gotest.tools/assert"
+)
+
+func TestDecryptTransfer(t *testing.T) {
+	sender := mustAccount(t, CreateAccount("0x20a89bb465e9e2262e25901525509686f6a26b2fba976f1d9ff00a0cdbb362b0"))
+	receiver := mustAccount(t, CreateAccount("0x299569ae0ae1d40140fd8d9afc54d2f581a292fd13fe88c7033d488119bb95b7"))
+
+	// registered accounts holding 10 and 0, CL = g^b + y^r and CR = g^r.
+	r := ebigint.NewNBigInt(99).ToRed(b128.Q())
+	var accounts = make([][2]types.Point, 2)
+	for i, a := range []core.Account{sender, receiver} {
+		y, _ := b128.DecodePoint(a.Y)
+		b := ebigint.NewNBigInt(int64(10 * (1 - i))).ToRed(b128.Q())
+		accounts[i] = [2]types.Point{
+			b128.Serialize(core.FixedG().Mul(b).Add(y.Mul(r))),
+			b128.Serialize(core.FixedG().Mul(r)),
+		}
+	}
+	tx, e := proveTransfer(TransferProofParam{
+		Epoch:    53712840,
+		Value:    3,
+		Diff:     7,
+		SK:       b128.Bytes(sender.X.Int),
+		Y:        []types.Point{sender.Y, receiver.Y},
+		Index:    []int{0, 1},
+		Accounts: accounts,
+	}, testRandom())
+	assert.NilError(t, e)
+	calldata := core.TransferSelector + txTransferData(*tx)[2:]
+
+	var got core.DecryptedTransfer
+	assert.NilError(t, json.Unmarshal([]byte(DecryptTransfer(`{"calldata":"`+calldata+`","x":"`+b128.Bytes(sender.X.Int)+`"}`)), &got))
+	assert.Equal(t, got, core.DecryptedTransfer{Index: 0, Role: core.TransferSender, Value: 3})
+
+	txJSON, _ := json.Marshal(tx)
+	var param DecryptTransferParam
+	assert.NilError(t, json.Unmarshal(txJSON, &param))
+	param.X = b128.Bytes(receiver.X.Int)
+	data, _ := json.Marshal(param)
+	assert.NilError(t, json.Unmarshal([]byte(DecryptTransfer(string(data))), &got))
+	assert.Equal(t, got, core.DecryptedTransfer{Index: 1, Role: core.TransferReceiver, Value: 3})
+
+	other := mustAccount(t, CreateAccount(""))
+	assert.Equal(t, DecryptTransfer(`{"calldata":"`+calldata+`","x":"`+b128.Bytes(other.X.Int)+`"}`), "")
+	assert.Equal(t, DecryptTransfer(`{"calldata":"`+calldata+`"}`), "")
+}
@@ -0,0 +1,71 @@
+package client
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"strings"
+
+	ethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/hpb-project/HCash-SDK/core"
+)
+
+/*
+ * input: {'privateKey':'0x...'}, the Ethereum private key, or
+	{'signature':'0x...', 'address':'0x...'}, the personal_sign signature of
+	the message of EthKeyMessage by address, for wallets that do not expose
+	the key. Both give the same account.
+ * output: {'x':'', 'y':{'gx':'', 'gy':''}}
+*/
+type CreateAccountFromEthKeyParam struct {
+	PrivateKey string `json:"privateKey"`
+	Signature  string `json:"signature"`
+	Address    string `json:"address"`
+}
+
+func CreateAccountFromEthKey(input string) string {
+	var param CreateAccountFromEthKeyParam
+	if e := json.Unmarshal([]byte(input), &param); e != nil {
+		log.Printf("unmarshal param failed, err:%s\n", e.Error())
+		return ""
+	}
+	account, e := createAccountFromEthKey(param)
+	if e != nil {
+		log.Printf("create account from eth key failed, err:%s\n", e.Error())
+		return ""
+	}
+	data, _ := json.Marshal(account)
+	return string(data)
+}
+
+func createAccountFromEthKey(param CreateAccountFromEthKeyParam) (core.Account, error) {
+	if param.PrivateKey != "" {
+		priv, e := crypto.HexToECDSA(strings.TrimPrefix(strings.TrimPrefix(param.PrivateKey, "0x"), "0X"))
+		if e != nil {
+			return core.Account{}, e
+		}
+		return core.CreateAccountFromEthKey(priv)
+	}
+	if !ethcommon.IsHexAddress(param.Address) {
+		return core.Account{}, errors.New("want privateKey, or signature and address")
+	}
+	return core.CreateAccountFromEthSignature(ethcommon.FromHex(param.Signature), ethcommon.HexToAddress(param.Address))
+}
+
+/*
+ * output: {'message':'', 'hash':'0x...'}, the message to sign with
+	personal_sign for CreateAccountFromEthKey and its EIP-191 hash.
+*/
+type EthKeyMessageResponse struct {
+	Message string `json:"message"`
+	Hash    string `json:"hash"`
+}
+
+func EthKeyMessage() string {
+	b, _ := json.Marshal(EthKeyMessageResponse{
+		Message: core.EthKeyMessage,
+		Hash:    "0x" + ethcommon.Bytes2Hex(core.EthKeyMessageHash()),
+	})
+	return string(b)
+}
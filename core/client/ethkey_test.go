@@ -0,0 +1,23 @@
+package client
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/hpb-project/HCash-SDK/core"
+	"gotest.tools/assert"
+)
+
+func TestCreateAccountFromEthKey(t *testing.T) {
+	var expected = `{"x":"0x2710e055c45c34d6bb9da844e1a8c1d435493ae9ed97c8efb829835ca6e4162","y":{"gx":"0x2834ddb6ca0ca4ab00ed1533c8d702c524fa29720982d941f994279d28b83062","gy":"0x28b36ed4ff329a886cde6acf9085f4b1cf4661932ce0de7e7dc3cd462cf6ea60"}}`
+	assert.Equal(t, CreateAccountFromEthKey(`{"privateKey":"0x4c0883a69102937d6231471b5dbb6204fe5129617082792ae468d01a3f362318"}`), expected)
+	assert.Equal(t, CreateAccountFromEthKey(`{
+		"signature":"0x6ce477bb28d6fc0549874c8679d1a2345aeffceb693b72f7227b6a9f5e58539a3c0f70a213b67d9566a93fa0e99abec2f938867f86053f7bbe6f38cfd2aa51a21c",
+		"address":"0x2c7536E3605D9C16a7a3D7b1898e529396a65c23"}`), expected)
+	assert.Equal(t, CreateAccountFromEthKey(`{"signature":"0x00"}`), "")
+
+	var msg EthKeyMessageResponse
+	assert.NilError(t, json.Unmarshal([]byte(EthKeyMessage()), &msg))
+	assert.Equal(t, msg.Message, core.EthKeyMessage)
+	assert.Equal(t, msg.Hash, "0x479387997fc7139cefc79f40578e5b0cd420e428d62c72e4a213972eb7c061ec")
+}
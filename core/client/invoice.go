@@ -0,0 +1,175 @@
+package client
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	ethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/hpb-project/HCash-SDK/common/types"
+	"github.com/hpb-project/HCash-SDK/core"
+)
+
+/*
+ * input: {'account':{'x':'', 'y':{'gx':'', 'gy':''}}, 'amount':0,
+	'contract':'0x...', 'chainId':0, 'ttl':seconds, 'memo':'', 'prefix':'hc'},
+	keySocket replaces account, an amount of 0 leaves it to the sender.
+ * output: {'invoice':{'y':{}, 'amount':0, 'contract':'', 'chainId':0,
+	'expiry':0, 'memo':'', 'nonce':'', 'c':'', 's':''}, 'uri':'hcash:...'}
+*/
+type CreateInvoiceParam struct {
+	Accounter core.Account `json:"account"`
+	KeySocket string       `json:"keySocket,omitempty"`
+	Amount    uint32       `json:"amount"`
+	Contract  string       `json:"contract"`
+	ChainID   uint64       `json:"chainId"`
+	TTL       int64        `json:"ttl"`
+	Memo      string       `json:"memo,omitempty"`
+	Prefix    string       `json:"prefix,omitempty"`
+}
+
+type InvoiceResponse struct {
+	Invoice core.Invoice `json:"invoice"`
+	URI     string       `json:"uri"`
+}
+
+func CreateInvoice(input string) string {
+	var param CreateInvoiceParam
+	if e := json.Unmarshal([]byte(input), &param); e != nil {
+		log.Printf("unmarshal param failed, err:%s\n", e.Error())
+		return ""
+	}
+	res, e := createInvoice(param, time.Now())
+	if e != nil {
+		log.Printf("create invoice failed, err:%s\n", e.Error())
+		return ""
+	}
+	data, _ := json.Marshal(res)
+	return string(data)
+}
+
+func createInvoice(param CreateInvoiceParam, now time.Time) (*InvoiceResponse, error) {
+	if !ethcommon.IsHexAddress(param.Contract) {
+		return nil, errors.New("invalid contract address")
+	}
+	if param.TTL <= 0 {
+		return nil, errors.New("ttl is required")
+	}
+	if param.Prefix == "" {
+		param.Prefix = types.MainnetPrefix
+	}
+	var key core.KeyHandle
+	socket, e := dialKey(param.KeySocket)
+	if e != nil {
+		return nil, e
+	}
+	if socket != nil {
+		defer socket.Close()
+		key = socket
+		if param.Accounter.Y, e = socket.PublicKey(); e != nil {
+			return nil, e
+		}
+	} else {
+		if param.Accounter.X == nil {
+			return nil, errors.New("account or keySocket is required")
+		}
+		key = core.NewMemoryKey(param.Accounter.X)
+	}
+
+	var invoice = core.Invoice{
+		Y:        param.Accounter.Y,
+		Amount:   param.Amount,
+		Contract: ethcommon.HexToAddress(param.Contract),
+		ChainID:  param.ChainID,
+		Expiry:   now.Unix() + param.TTL,
+		Memo:     param.Memo,
+	}
+	if e := invoice.Sign(key); e != nil {
+		return nil, e
+	}
+	uri, e := invoice.URI(param.Prefix)
+	if e != nil {
+		return nil, e
+	}
+	return &InvoiceResponse{Invoice: invoice, URI: uri}, nil
+}
+
+/*
+ * input: {'uri':'hcash:...'}
+ * output: {'invoice':{...}, 'uri':'hcash:...'}, the invoice is not verified,
+	see VerifyInvoice.
+*/
+func ParseInvoice(input string) string {
+	var param InvoiceResponse
+	if e := json.Unmarshal([]byte(input), &param); e != nil {
+		log.Printf("unmarshal param failed, err:%s\n", e.Error())
+		return ""
+	}
+	invoice, e := core.ParseInvoiceURI(param.URI)
+	if e != nil {
+		log.Printf("parse invoice failed, err:%s\n", e.Error())
+		return ""
+	}
+	data, _ := json.Marshal(InvoiceResponse{Invoice: invoice, URI: param.URI})
+	return string(data)
+}
+
+/*
+ * input: {'invoice':{...}} or {'uri':'hcash:...'}, with the 'contract' and
+	'chainId' the wallet pays on.
+ * output: {'valid':true} or {'valid':false, 'reason':''}
+	TransferProof takes the same object as 'invoice' and checks it before
+	proving, against the receiver y[index[1]] and the value.
+*/
+type VerifyInvoiceParam struct {
+	Invoice  *core.Invoice `json:"invoice,omitempty"`
+	URI      string        `json:"uri,omitempty"`
+	Contract string        `json:"contract"`
+	ChainID  uint64        `json:"chainId"`
+}
+
+func VerifyInvoice(input string) string {
+	var param VerifyInvoiceParam
+	if e := json.Unmarshal([]byte(input), &param); e != nil {
+		log.Printf("unmarshal param failed, err:%s\n", e.Error())
+		return ""
+	}
+	_, e := verifyInvoice(param, time.Now())
+	return verifyResult(e)
+}
+
+func verifyInvoice(param VerifyInvoiceParam, now time.Time) (core.Invoice, error) {
+	var invoice core.Invoice
+	if param.Invoice != nil {
+		invoice = *param.Invoice
+	} else {
+		var e error
+		if invoice, e = core.ParseInvoiceURI(param.URI); e != nil {
+			return core.Invoice{}, e
+		}
+	}
+	if !ethcommon.IsHexAddress(param.Contract) {
+		return core.Invoice{}, errors.New("invalid contract address")
+	}
+	if e := invoice.Verify(ethcommon.HexToAddress(param.Contract), param.ChainID, now); e != nil {
+		return core.Invoice{}, e
+	}
+	return invoice, nil
+}
+
+// checkInvoice verifies the invoice of a transfer of value to y.
+func checkInvoice(param VerifyInvoiceParam, y types.Point, value int, now time.Time) error {
+	invoice, e := verifyInvoice(param, now)
+	if e != nil {
+		return e
+	}
+	if invoice.Y != y {
+		return fmt.Errorf("%w: receiver is not the invoice recipient", core.ErrInvalidInvoice)
+	}
+	if invoice.Amount != 0 && int64(invoice.Amount) != int64(value) {
+		return fmt.Errorf("%w: value %d, invoice asks %d", core.ErrInvalidInvoice, value, invoice.Amount)
+	}
+	return nil
+}
@@ -0,0 +1,55 @@
+package client
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hpb-project/HCash-SDK/common/types"
+	"github.com/hpb-project/HCash-SDK/core"
+	"gotest.tools/assert"
+)
+
+func TestInvoice(t *testing.T) {
+	account := mustAccount(t, CreateAccount("0x20a89bb465e9e2262e25901525509686f6a26b2fba976f1d9ff00a0cdbb362b0"))
+	accountJSON, _ := json.Marshal(account)
+	const zsc = "0xE4920905e06c6B6070477c40B85756ffDa3cD3E6"
+	var created InvoiceResponse
+	result := CreateInvoice(`{"account":` + string(accountJSON) + `,"amount":5,"contract":"` + zsc + `","chainId":269,"ttl":600,"memo":"coffee"}`)
+	assert.NilError(t, json.Unmarshal([]byte(result), &created))
+	assert.Assert(t, strings.HasPrefix(created.URI, "hcash:hc1"), created.URI)
+	assert.Equal(t, created.Invoice.Y, account.Y)
+
+	var parsed InvoiceResponse
+	assert.NilError(t, json.Unmarshal([]byte(ParseInvoice(`{"uri":"`+created.URI+`"}`)), &parsed))
+	a, _ := json.Marshal(parsed.Invoice)
+	b, _ := json.Marshal(created.Invoice)
+	assert.Equal(t, string(a), string(b))
+
+	invoiceJSON, _ := json.Marshal(created.Invoice)
+	assert.Equal(t, VerifyInvoice(`{"uri":"`+created.URI+`","contract":"`+zsc+`","chainId":269}`), `{"valid":true}`)
+	assert.Equal(t, VerifyInvoice(`{"invoice":`+string(invoiceJSON)+`,"contract":"`+zsc+`","chainId":269}`), `{"valid":true}`)
+	assert.Equal(t, VerifyInvoice(`{"uri":"`+created.URI+`","contract":"`+zsc+`","chainId":1}`), `{"valid":false,"reason":"invalid invoice: for chain 269"}`)
+
+	var param = VerifyInvoiceParam{URI: created.URI, Contract: zsc, ChainID: 269}
+	now := time.Now()
+	assert.NilError(t, checkInvoice(param, account.Y, 5, now))
+	assert.Assert(t, errors.Is(checkInvoice(param, account.Y, 6, now), core.ErrInvalidInvoice))
+	assert.Assert(t, errors.Is(checkInvoice(param, mustAccount(t, CreateAccount("")).Y, 5, now), core.ErrInvalidInvoice))
+	assert.Assert(t, errors.Is(checkInvoice(param, account.Y, 5, now.Add(time.Hour)), core.ErrInvoiceExpired))
+
+	// a transfer of 1 does not pay the invoice.
+	var transfer TransferProofParam
+	transfer.Y = []types.Point{account.Y, account.Y}
+	transfer.Accounts = make([][2]types.Point, 2)
+	transfer.Index = []int{0, 1}
+	transfer.Value = 1
+	transfer.Invoice = &param
+	_, e := proveTransfer(transfer, testRandom())
+	assert.Assert(t, errors.Is(e, core.ErrInvalidInvoice))
+
+	assert.Equal(t, CreateInvoice(`{"account":`+string(accountJSON)+`,"contract":"`+zsc+`","chainId":269}`), "")
+	assert.Equal(t, ParseInvoice(`{"uri":"hcash:`+created.URI[len("hcash:x"):]+`"}`), "")
+}
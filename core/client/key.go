@@ -0,0 +1,25 @@
+package client
+
+import (
+	"github.com/hpb-project/HCash-SDK/common/types"
+	"github.com/hpb-project/HCash-SDK/core"
+	"github.com/hpb-project/HCash-SDK/core/ebigint"
+)
+
+// dialKey connects to the key process serving on socket, see core.ServeKey.
+// An empty socket is no key, the caller then uses its 'sk' or 'x'.
+func dialKey(socket string) (*core.SocketKey, error) {
+	if socket == "" {
+		return nil, nil
+	}
+	return core.DialKey(socket)
+}
+
+// proofNonce is the u sent along with a proof of epoch, from the key when
+// there is one.
+func proofNonce(key *core.SocketKey, sk string, epoch int) (types.Point, error) {
+	if key != nil {
+		return key.U(epoch)
+	}
+	return b128.Serialize(core.U(epoch, ebigint.FromHex(sk))), nil
+}
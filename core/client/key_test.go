@@ -0,0 +1,69 @@
+package client
+
+import (
+	"encoding/json"
+	"net"
+	"path/filepath"
+	"testing"
+
+	"github.com/hpb-project/HCash-SDK/common/types"
+	"github.com/hpb-project/HCash-SDK/core"
+	"github.com/hpb-project/HCash-SDK/core/ebigint"
+	"gotest.tools/assert"
+)
+
+func TestKeySocket(t *testing.T) {
+	var sk = "0x04907c94209e3442e4830c142ba166ac032e511d00fcdf5f01b77d480518fa1a"
+	var param = BurnProofParam{
+		Epoch:  53672920,
+		Value:  1,
+		Diff:   99,
+		SK:     sk,
+		Y:      types.MustPoint("0x2af593d93442ca5d86d1f3748e624e68cc7db78da5fa568c40e32753e2e5b64b", "0x301248643b2813c1aaa9fbb7cec25fa6fb8e6d6db1240649b848a545962a9f81"),
+		Sender: "0xd80ac1fb177c0b8d9c66de2b9657dd57084a2d7f",
+	}
+	param.Accounts = append(param.Accounts,
+		types.MustPoint("0x19512743220081b7244cae299bb9f053b25d27337ee6b5d760eae272117db2af", "0x0f7dae3691a53ec20f37e534c34c0eb41d256c8c7f9472e4c618126d6a054b58"),
+		types.MustPoint("0x077da99d806abd13c9f15ece5398525119d11e11e9836b2ee7d23f6159ad87d4", "0x01485efa927f2ad41bff567eec88f32fb0a0f706588b4e41a8d587d008b7f875"))
+	withSK, err := proveBurn(param, testRandom())
+	assert.NilError(t, err)
+
+	path := filepath.Join(t.TempDir(), "key.sock")
+	l, err := net.Listen("unix", path)
+	assert.NilError(t, err)
+	defer l.Close()
+	key := core.NewMemoryKey(ebigint.FromHex(sk))
+	key.SetRandom(testRandom())
+	go core.ServeKey(l, key)
+
+	// the same proof without the sk in this process.
+	param.SK = ""
+	param.KeySocket = path
+	withKey, err := proveBurn(param, testRandom())
+	assert.NilError(t, err)
+	assert.DeepEqual(t, withKey, withSK)
+
+	input, _ := json.Marshal(ReadBalanceParam{CL: param.Accounts[0], CR: param.Accounts[1], KeySocket: path})
+	assert.Equal(t, ReadBalance(string(input)), 100)
+
+	// the signatures are deterministic, the key process makes the same ones.
+	account := mustAccount(t, CreateAccount(sk))
+	address := "0xE4920905e06c6B6070477c40B85756ffDa3cD3E6"
+	input, _ = json.Marshal(SignParam{ZSCAddr: address, Accounter: account})
+	withX := Sign(string(input))
+	assert.Assert(t, withX != "")
+	assert.Equal(t, Sign(`{"address":"`+address+`","keySocket":"`+path+`"}`), withX)
+
+	input, _ = json.Marshal(SignMessageParam{Accounter: account, Message: "hello"})
+	withX = SignMessage(string(input))
+	assert.Assert(t, withX != "")
+	assert.Equal(t, SignMessage(`{"keySocket":"`+path+`","message":"hello"}`), withX)
+
+	var challenge = LoginChallenge{Domain: "example.com", Nonce: "0x01", Expires: 1700000000}
+	input, _ = json.Marshal(SignLoginParam{Accounter: account, Challenge: challenge})
+	withX = SignLogin(string(input))
+	data, _ := json.Marshal(challenge)
+	assert.Assert(t, withX != "")
+	assert.Equal(t, SignLogin(`{"keySocket":"`+path+`","challenge":`+string(data)+`}`), withX)
+	assert.Equal(t, SignLogin(`{"challenge":`+string(data)+`}`), "")
+}
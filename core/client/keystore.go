@@ -0,0 +1,128 @@
+package client
+
+import (
+	crand "crypto/rand"
+	"encoding/json"
+	"io"
+	"log"
+	"math/big"
+
+	"github.com/hpb-project/HCash-SDK/common"
+	"github.com/hpb-project/HCash-SDK/core"
+	"github.com/hpb-project/HCash-SDK/core/ebigint"
+)
+
+/*
+ * input: {'password':'', 'kdf':'scrypt', 'light':false}, kdf is 'scrypt' or
+	'argon2id', light uses the cheaper parameters for mobile devices.
+ * output: the keystore of a new account,
+	{'version':1, 'y':{'gx':'', 'gy':''}, 'crypto':{'cipher':'aes-256-gcm', 'ciphertext':'',
+	 'nonce':'', 'kdf':'', 'kdfparams':{...}}}
+*/
+type CreateKeystoreParam struct {
+	Password string `json:"password"`
+	core.KeystoreOptions
+}
+
+func CreateKeystore(input string) string {
+	return createKeystore(input, crand.Reader)
+}
+
+func createKeystore(input string, random io.Reader) string {
+	var param CreateKeystoreParam
+	if e := json.Unmarshal([]byte(input), &param); e != nil {
+		log.Printf("unmarshal param failed, err:%s\n", e.Error())
+		return ""
+	}
+	account, e := core.CreateAccountWithReader(random)
+	if e != nil {
+		log.Printf("create account failed, err:%s\n", e.Error())
+		return ""
+	}
+	return encryptKeystore(account, param.Password, param.KeystoreOptions)
+}
+
+/*
+ * input: {'x':'', 'password':'', 'kdf':'scrypt', 'light':false}
+ * output: the keystore of x.
+ */
+type ImportKeystoreParam struct {
+	X        string `json:"x"`
+	Password string `json:"password"`
+	core.KeystoreOptions
+}
+
+func ImportKeystore(input string) string {
+	var param ImportKeystoreParam
+	if e := json.Unmarshal([]byte(input), &param); e != nil {
+		log.Printf("unmarshal param failed, err:%s\n", e.Error())
+		return ""
+	}
+	x, ok := new(big.Int).SetString(common.HexWithout0x(param.X), 16)
+	if !ok || x.Sign() <= 0 || x.Cmp(b128.Q().Int) >= 0 {
+		log.Printf("import keystore failed, err:%s: x\n", core.ErrInvalidScalar)
+		return ""
+	}
+	account := core.CreateAccountWithX(ebigint.ToNBigInt(x).ForceRed(b128.Q()))
+	return encryptKeystore(account, param.Password, param.KeystoreOptions)
+}
+
+func encryptKeystore(account core.Account, password string, opts core.KeystoreOptions) string {
+	ks, e := core.EncryptKey(account, password, opts)
+	if e != nil {
+		log.Printf("encrypt keystore failed, err:%s\n", e.Error())
+		return ""
+	}
+	data, _ := json.Marshal(ks)
+	return string(data)
+}
+
+/*
+ * input: {'keystore':{...}, 'password':''}
+ * output: the account, {'x':'', 'y':{'gx':'', 'gy':''}}
+ */
+type ExportKeystoreParam struct {
+	Keystore core.Keystore `json:"keystore"`
+	Password string        `json:"password"`
+}
+
+func ExportKeystore(input string) string {
+	var param ExportKeystoreParam
+	if e := json.Unmarshal([]byte(input), &param); e != nil {
+		log.Printf("unmarshal param failed, err:%s\n", e.Error())
+		return ""
+	}
+	account, e := param.Keystore.Decrypt(param.Password)
+	if e != nil {
+		log.Printf("decrypt keystore failed, err:%s\n", e.Error())
+		return ""
+	}
+	data, _ := json.Marshal(account)
+	return string(data)
+}
+
+/*
+ * input: {'keystore':{...}, 'password':'', 'newPassword':'', 'kdf':'scrypt', 'light':false}
+ * output: the keystore under the new password.
+ */
+type ChangeKeystorePasswordParam struct {
+	Keystore    core.Keystore `json:"keystore"`
+	Password    string        `json:"password"`
+	NewPassword string        `json:"newPassword"`
+	core.KeystoreOptions
+}
+
+func ChangeKeystorePassword(input string) string {
+	var param ChangeKeystorePasswordParam
+	if e := json.Unmarshal([]byte(input), &param); e != nil {
+		log.Printf("unmarshal param failed, err:%s\n", e.Error())
+		return ""
+	}
+	ks, e := param.Keystore.ChangePassword(param.Password, param.NewPassword, param.KeystoreOptions)
+	if e != nil {
+		log.Printf("change keystore password failed, err:%s\n", e.Error())
+		return ""
+	}
+	data, _ := json.Marshal(ks)
+	return string(data)
+}
@@ -0,0 +1,38 @@
+package client
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/hpb-project/HCash-SDK/core"
+	"gotest.tools/assert"
+)
+
+func TestKeystore(t *testing.T) {
+	var x = "0x04907c94209e3442e4830c142ba166ac032e511d00fcdf5f01b77d480518fa1a"
+	ks := ImportKeystore(`{"x":"` + x + `", "password":"hcash", "kdf":"argon2id", "light":true}`)
+	assert.Assert(t, ks != "")
+
+	account := mustAccount(t, ExportKeystore(`{"keystore":`+ks+`, "password":"hcash"}`))
+	assert.Equal(t, account.String(), CreateAccount(x))
+	assert.Equal(t, ExportKeystore(`{"keystore":`+ks+`, "password":"wrong"}`), "")
+
+	changed := ChangeKeystorePassword(`{"keystore":` + ks + `, "password":"hcash", "newPassword":"new", "light":true}`)
+	var keystore core.Keystore
+	assert.NilError(t, json.Unmarshal([]byte(changed), &keystore))
+	assert.Equal(t, keystore.Crypto.KDF, core.KDFScrypt)
+	assert.Equal(t, keystore.Y, account.Y)
+	assert.Equal(t, ExportKeystore(`{"keystore":`+changed+`, "password":"new"}`), CreateAccount(x))
+
+	created := createKeystore(`{"password":"", "light":true}`, core.NewDRBG(nil))
+	assert.NilError(t, json.Unmarshal([]byte(created), &keystore))
+	assert.Equal(t, keystore.Y, core.CreateAccountWithX(mustAccount(t, ExportKeystore(`{"keystore":`+created+`}`)).X).Y)
+
+	assert.Equal(t, ImportKeystore(`{"x":"0x00", "password":""}`), "")
+}
+
+func mustAccount(t *testing.T, s string) core.Account {
+	var account core.Account
+	assert.NilError(t, json.Unmarshal([]byte(s), &account))
+	return account
+}
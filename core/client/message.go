@@ -0,0 +1,206 @@
+package client
+
+import (
+	crand "crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"math/big"
+	"time"
+
+	"github.com/hpb-project/HCash-SDK/common"
+	"github.com/hpb-project/HCash-SDK/common/types"
+	"github.com/hpb-project/HCash-SDK/core"
+	"github.com/hpb-project/HCash-SDK/core/ebigint"
+)
+
+/*
+ * input: {'account':{'x':'', 'y':{'gx':'', 'gy':''}}, 'message':''},
+	keySocket replaces account.
+ * output: {'y':{'gx':'', 'gy':''}, 'c':'', 's':''}
+ */
+type SignMessageParam struct {
+	Accounter core.Account `json:"account"`
+	KeySocket string       `json:"keySocket,omitempty"`
+	Message   string       `json:"message"`
+}
+
+type MessageSignature struct {
+	Y types.Point `json:"y"`
+	C string      `json:"c"`
+	S string      `json:"s"`
+}
+
+// signMessage signs message with the key process on keySocket, or with
+// account when there is none.
+func signMessage(account core.Account, keySocket string, message []byte) (*MessageSignature, error) {
+	socket, err := dialKey(keySocket)
+	if err != nil {
+		return nil, err
+	}
+	if socket == nil {
+		if account.X == nil {
+			return nil, errors.New("account or keySocket is required")
+		}
+		c, s, err := core.SignMessage(account, message)
+		if err != nil {
+			return nil, err
+		}
+		return &MessageSignature{Y: account.Y, C: b128.Bytes(c.Int), S: b128.Bytes(s.Int)}, nil
+	}
+	defer socket.Close()
+	y, err := socket.PublicKey()
+	if err != nil {
+		return nil, err
+	}
+	c, s, err := socket.SignMessage(message)
+	if err != nil {
+		return nil, err
+	}
+	return &MessageSignature{Y: y, C: b128.Bytes(c.Int), S: b128.Bytes(s.Int)}, nil
+}
+
+func verifyMessage(sig MessageSignature, message []byte) error {
+	c, okc := new(big.Int).SetString(common.HexWithout0x(sig.C), 16)
+	s, oks := new(big.Int).SetString(common.HexWithout0x(sig.S), 16)
+	if !okc || !oks {
+		return core.ErrInvalidSignature
+	}
+	return core.VerifyMessage(sig.Y, message, ebigint.ToNBigInt(c), ebigint.ToNBigInt(s))
+}
+
+func SignMessage(input string) string {
+	var param SignMessageParam
+	if e := json.Unmarshal([]byte(input), &param); e != nil {
+		log.Printf("unmarshal param failed, err:%s\n", e.Error())
+		return ""
+	}
+	sig, e := signMessage(param.Accounter, param.KeySocket, []byte(param.Message))
+	if e != nil {
+		log.Printf("sign message failed, err:%s\n", e.Error())
+		return ""
+	}
+	data, _ := json.Marshal(sig)
+	return string(data)
+}
+
+/*
+ * input: {'message':'', 'signature':{'y':{'gx':'', 'gy':''}, 'c':'', 's':''}}
+ * output: {'valid':true} or {'valid':false, 'reason':''}
+ */
+type VerifyMessageParam struct {
+	Message   string           `json:"message"`
+	Signature MessageSignature `json:"signature"`
+}
+
+func VerifyMessage(input string) string {
+	var param VerifyMessageParam
+	if e := json.Unmarshal([]byte(input), &param); e != nil {
+		log.Printf("unmarshal param failed, err:%s\n", e.Error())
+		return ""
+	}
+	return verifyResult(verifyMessage(param.Signature, []byte(param.Message)))
+}
+
+// LoginChallenge is issued by a backend and signed by the wallet to log in
+// with its Zether key. The nonce makes every challenge single use, the
+// backend has to remember the nonces it issued until they expire.
+type LoginChallenge struct {
+	Domain  string `json:"domain"`
+	Nonce   string `json:"nonce"`
+	Expires int64  `json:"expires"`
+}
+
+func (l LoginChallenge) message() []byte {
+	return []byte(fmt.Sprintf("HCash login\ndomain: %s\nnonce: %s\nexpires: %d", l.Domain, l.Nonce, l.Expires))
+}
+
+/*
+ * input: {'domain':'', 'ttl':seconds}
+ * output: {'domain':'', 'nonce':'', 'expires':unix seconds}
+ */
+type NewLoginChallengeParam struct {
+	Domain string `json:"domain"`
+	TTL    int64  `json:"ttl"`
+}
+
+func NewLoginChallenge(input string) string {
+	var param NewLoginChallengeParam
+	if e := json.Unmarshal([]byte(input), &param); e != nil {
+		log.Printf("unmarshal param failed, err:%s\n", e.Error())
+		return ""
+	}
+	if param.Domain == "" || param.TTL <= 0 {
+		log.Printf("invalid login challenge param, domain and ttl are required\n")
+		return ""
+	}
+	var nonce = make([]byte, 32)
+	if _, e := crand.Read(nonce); e != nil {
+		log.Printf("read random failed, err:%s\n", e.Error())
+		return ""
+	}
+	var challenge = LoginChallenge{
+		Domain:  param.Domain,
+		Nonce:   "0x" + hex.EncodeToString(nonce),
+		Expires: time.Now().Unix() + param.TTL,
+	}
+	data, _ := json.Marshal(challenge)
+	return string(data)
+}
+
+/*
+ * input: {'account':{'x':'', 'y':{'gx':'', 'gy':''}}, 'challenge':{'domain':'', 'nonce':'', 'expires':0}},
+	keySocket replaces account.
+ * output: {'y':{'gx':'', 'gy':''}, 'c':'', 's':''}
+ */
+type SignLoginParam struct {
+	Accounter core.Account   `json:"account"`
+	KeySocket string         `json:"keySocket,omitempty"`
+	Challenge LoginChallenge `json:"challenge"`
+}
+
+func SignLogin(input string) string {
+	var param SignLoginParam
+	if e := json.Unmarshal([]byte(input), &param); e != nil {
+		log.Printf("unmarshal param failed, err:%s\n", e.Error())
+		return ""
+	}
+	sig, e := signMessage(param.Accounter, param.KeySocket, param.Challenge.message())
+	if e != nil {
+		log.Printf("sign login failed, err:%s\n", e.Error())
+		return ""
+	}
+	data, _ := json.Marshal(sig)
+	return string(data)
+}
+
+/*
+ * input: {'domain':'', 'challenge':{...}, 'signature':{'y':{}, 'c':'', 's':''}}
+ * output: {'valid':true} or {'valid':false, 'reason':''}
+ */
+type VerifyLoginParam struct {
+	Domain    string           `json:"domain"`
+	Challenge LoginChallenge   `json:"challenge"`
+	Signature MessageSignature `json:"signature"`
+}
+
+func VerifyLogin(input string) string {
+	return verifyLogin(input, time.Now())
+}
+
+func verifyLogin(input string, now time.Time) string {
+	var param VerifyLoginParam
+	if e := json.Unmarshal([]byte(input), &param); e != nil {
+		log.Printf("unmarshal param failed, err:%s\n", e.Error())
+		return ""
+	}
+	if param.Challenge.Domain != param.Domain {
+		return verifyResult(errors.New("login challenge is for another domain"))
+	}
+	if now.Unix() > param.Challenge.Expires {
+		return verifyResult(errors.New("login challenge expired"))
+	}
+	return verifyResult(verifyMessage(param.Signature, param.Challenge.message()))
+}
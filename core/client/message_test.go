@@ -0,0 +1,50 @@
+package client
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"gotest.tools/assert"
+)
+
+const testAccount = `{
+	"x": "0x299569ae0ae1d40140fd8d9afc54d2f581a292fd13fe88c7033d488119bb95b7",
+	"y": {
+		"gx":"0x042526b090bc34791599c53df82a129307914728eb9dcafe4a56d66d6c7cc76f",
+		"gy":"0x09c7fcbde6288f52f715f460f495714606b1e11897d1cff6fd80c576f6b9a896"
+	}
+}`
+
+func TestSignMessage(t *testing.T) {
+	sig := SignMessage(`{"account":` + testAccount + `, "message":"hello"}`)
+	assert.Assert(t, sig != "")
+
+	valid := VerifyMessage(`{"message":"hello", "signature":` + sig + `}`)
+	assert.Equal(t, valid, `{"valid":true}`)
+	invalid := VerifyMessage(`{"message":"hello!", "signature":` + sig + `}`)
+	assert.Equal(t, invalid, `{"valid":false,"reason":"invalid signature"}`)
+}
+
+func TestLogin(t *testing.T) {
+	challenge := NewLoginChallenge(`{"domain":"example.com", "ttl":60}`)
+	assert.Assert(t, challenge != "")
+	var lc LoginChallenge
+	assert.NilError(t, json.Unmarshal([]byte(challenge), &lc))
+	assert.Equal(t, len(lc.Nonce), 66)
+
+	sig := SignLogin(`{"account":` + testAccount + `, "challenge":` + challenge + `}`)
+	assert.Assert(t, sig != "")
+
+	verify := func(domain string, now time.Time) string {
+		return verifyLogin(`{"domain":"`+domain+`", "challenge":`+challenge+`, "signature":`+sig+`}`, now)
+	}
+	assert.Equal(t, verify("example.com", time.Now()), `{"valid":true}`)
+	assert.Equal(t, verify("example.org", time.Now()), `{"valid":false,"reason":"login challenge is for another domain"}`)
+	assert.Equal(t, verify("example.com", time.Unix(lc.Expires+1, 0)), `{"valid":false,"reason":"login challenge expired"}`)
+
+	// the signature does not carry over to a fresh challenge.
+	other := NewLoginChallenge(`{"domain":"example.com", "ttl":60}`)
+	result := VerifyLogin(`{"domain":"example.com", "challenge":` + other + `, "signature":` + sig + `}`)
+	assert.Equal(t, result, `{"valid":false,"reason":"invalid signature"}`)
+}
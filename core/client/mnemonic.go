@@ -0,0 +1,107 @@
+package client
+
+import (
+	crand "crypto/rand"
+	"encoding/json"
+	"errors"
+	"io"
+	"log"
+
+	"github.com/hpb-project/HCash-SDK/common"
+	"github.com/hpb-project/HCash-SDK/core"
+)
+
+/*
+ * input: {'bits':128}, 128 to 256 bits of entropy in steps of 32, 0 is 128.
+ * output: {'mnemonic':'12 to 24 words'}
+ */
+type NewMnemonicParam struct {
+	Bits int `json:"bits"`
+}
+
+type MnemonicResponse struct {
+	Mnemonic string `json:"mnemonic"`
+}
+
+func NewMnemonic(input string) string {
+	return newMnemonic(input, crand.Reader)
+}
+
+func newMnemonic(input string, random io.Reader) string {
+	var param NewMnemonicParam
+	if e := json.Unmarshal([]byte(input), &param); e != nil {
+		log.Printf("unmarshal param failed, err:%s\n", e.Error())
+		return ""
+	}
+	if param.Bits == 0 {
+		param.Bits = 128
+	}
+	mnemonic, e := core.NewMnemonicWithReader(param.Bits, random)
+	if e != nil {
+		log.Printf("new mnemonic failed, err:%s\n", e.Error())
+		return ""
+	}
+	b, _ := json.Marshal(MnemonicResponse{Mnemonic: mnemonic})
+	return string(b)
+}
+
+/*
+ * input: {'mnemonic':''}
+ * output: {'valid':true} or {'valid':false, 'reason':''}
+ */
+func ValidateMnemonic(input string) string {
+	var param MnemonicResponse
+	if e := json.Unmarshal([]byte(input), &param); e != nil {
+		log.Printf("unmarshal param failed, err:%s\n", e.Error())
+		return ""
+	}
+	return verifyResult(core.ValidateMnemonic(param.Mnemonic))
+}
+
+/*
+ * input: {'mnemonic':'', 'passphrase':'', 'index':0}, or the bip39 seed
+	{'seed':'0x...', 'index':0} in place of the mnemonic. 'path' replaces the
+	index with a full path of hardened indexes, "m/44'/269'/0'/0'/0'".
+ * output: {'x':'', 'y':{'gx':'', 'gy':''}}
+*/
+type DeriveAccountParam struct {
+	Mnemonic   string `json:"mnemonic"`
+	Passphrase string `json:"passphrase"`
+	Seed       string `json:"seed"`
+	Index      uint32 `json:"index"`
+	Path       string `json:"path"`
+}
+
+func DeriveAccount(input string) string {
+	var param DeriveAccountParam
+	if e := json.Unmarshal([]byte(input), &param); e != nil {
+		log.Printf("unmarshal param failed, err:%s\n", e.Error())
+		return ""
+	}
+	account, e := deriveAccount(param)
+	if e != nil {
+		log.Printf("derive account failed, err:%s\n", e.Error())
+		return ""
+	}
+	data, _ := json.Marshal(account)
+	return string(data)
+}
+
+func deriveAccount(param DeriveAccountParam) (core.Account, error) {
+	var seed []byte
+	switch {
+	case param.Mnemonic != "" && param.Seed == "":
+		var e error
+		if seed, e = core.MnemonicToSeed(param.Mnemonic, param.Passphrase); e != nil {
+			return core.Account{}, e
+		}
+	case param.Seed != "" && param.Mnemonic == "":
+		seed = common.FromHex(param.Seed)
+	default:
+		return core.Account{}, errors.New("want exactly one of mnemonic and seed")
+	}
+	if param.Path != "" {
+		return core.DerivePath(seed, param.Path)
+	}
+	return core.DeriveAccount(seed, param.Index)
+}
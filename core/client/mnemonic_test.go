@@ -0,0 +1,32 @@
+package client
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"testing"
+
+	"github.com/hpb-project/HCash-SDK/core"
+	"gotest.tools/assert"
+)
+
+func TestDeriveAccount(t *testing.T) {
+	var res MnemonicResponse
+	assert.NilError(t, json.Unmarshal([]byte(newMnemonic(`{"bits":160}`, core.NewDRBG(nil))), &res))
+	assert.Equal(t, ValidateMnemonic(`{"mnemonic":"`+res.Mnemonic+`"}`), `{"valid":true}`)
+
+	seed, err := core.MnemonicToSeed(res.Mnemonic, "hcash")
+	assert.NilError(t, err)
+	fromMnemonic := DeriveAccount(`{"mnemonic":"` + res.Mnemonic + `", "passphrase":"hcash", "index":3}`)
+	fromSeed := DeriveAccount(`{"seed":"0x` + hex.EncodeToString(seed) + `", "path":"m/44'/269'/0'/0'/3'"}`)
+	assert.Assert(t, fromMnemonic != "")
+	assert.Equal(t, fromMnemonic, fromSeed)
+
+	account, err := core.DeriveAccount(seed, 3)
+	assert.NilError(t, err)
+	assert.Equal(t, fromMnemonic, account.String())
+
+	// other passphrase, other accounts.
+	assert.Assert(t, DeriveAccount(`{"mnemonic":"`+res.Mnemonic+`", "index":3}`) != fromMnemonic)
+	assert.Equal(t, DeriveAccount(`{"mnemonic":"`+res.Mnemonic+` abandon", "index":3}`), "")
+	assert.Equal(t, DeriveAccount(`{"index":3}`), "")
+}
@@ -0,0 +1,63 @@
+package client
+
+import (
+	crand "crypto/rand"
+	"encoding/json"
+	"io"
+	"log"
+
+	"github.com/hpb-project/HCash-SDK/core"
+)
+
+/*
+ * input: {'account':{'x':'', 'y':{'gx':'', 'gy':''}}, 'threshold':2, 'shares':3}
+ * output: {'shares':[{'y':{'gx':'', 'gy':''}, 'threshold':2, 'index':1, 'value':'', 'checksum':''}, ...]},
+	one share for each guardian, any threshold of them recover the account.
+*/
+type SplitAccountParam struct {
+	Accounter core.Account `json:"account"`
+	Threshold int          `json:"threshold"`
+	Shares    int          `json:"shares"`
+}
+
+type KeySharesParam struct {
+	Shares []core.KeyShare `json:"shares"`
+}
+
+func SplitAccount(input string) string {
+	return splitAccount(input, crand.Reader)
+}
+
+func splitAccount(input string, random io.Reader) string {
+	var param SplitAccountParam
+	if e := json.Unmarshal([]byte(input), &param); e != nil {
+		log.Printf("unmarshal param failed, err:%s\n", e.Error())
+		return ""
+	}
+	shares, e := core.SplitAccountWithReader(param.Accounter, param.Threshold, param.Shares, random)
+	if e != nil {
+		log.Printf("split account failed, err:%s\n", e.Error())
+		return ""
+	}
+	b, _ := json.Marshal(KeySharesParam{Shares: shares})
+	return string(b)
+}
+
+/*
+ * input: {'shares':[...]}, at least threshold shares of SplitAccount.
+ * output: {'x':'', 'y':{'gx':'', 'gy':''}}, after checking y = g^x.
+ */
+func CombineShares(input string) string {
+	var param KeySharesParam
+	if e := json.Unmarshal([]byte(input), &param); e != nil {
+		log.Printf("unmarshal param failed, err:%s\n", e.Error())
+		return ""
+	}
+	account, e := core.CombineShares(param.Shares)
+	if e != nil {
+		log.Printf("combine shares failed, err:%s\n", e.Error())
+		return ""
+	}
+	b, _ := json.Marshal(account)
+	return string(b)
+}
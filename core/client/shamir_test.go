@@ -0,0 +1,23 @@
+package client
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/hpb-project/HCash-SDK/core"
+	"gotest.tools/assert"
+)
+
+func TestSplitAccount(t *testing.T) {
+	var account = CreateAccount("0x20a89bb465e9e2262e25901525509686f6a26b2fba976f1d9ff00a0cdbb362b0")
+	result := splitAccount(`{"account":`+account+`, "threshold":2, "shares":3}`, core.NewDRBG(nil))
+	var param KeySharesParam
+	assert.NilError(t, json.Unmarshal([]byte(result), &param))
+	assert.Equal(t, len(param.Shares), 3)
+
+	two, _ := json.Marshal(KeySharesParam{Shares: param.Shares[1:]})
+	assert.Equal(t, CombineShares(string(two)), account)
+	one, _ := json.Marshal(KeySharesParam{Shares: param.Shares[:1]})
+	assert.Equal(t, CombineShares(string(one)), "")
+	assert.Equal(t, SplitAccount(`{"account":`+account+`, "threshold":4, "shares":3}`), "")
+}
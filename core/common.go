@@ -1,10 +1,12 @@
 package core
 
 import (
+	"encoding/hex"
 	"fmt"
 	ethcommon "github.com/ethereum/go-ethereum/common"
 	"github.com/hpb-project/HCash-SDK/common"
 	"github.com/hpb-project/HCash-SDK/common/types"
+	"github.com/hpb-project/HCash-SDK/core/bn256"
 	"github.com/hpb-project/HCash-SDK/core/ebigint"
 	"log"
 )
@@ -43,21 +45,23 @@ type TransferWitness struct {
 	BTransfer int
 	BDiff     int
 	Index     []int
-	SK        string // keypair['x'], bigInt hex string
-	R         string // random scalar, bigInt hex string
+	SK        string    // keypair['x'], bigInt hex string
+	R         string    // random scalar, bigInt hex string
+	Key       KeyHandle // replaces SK when set
 }
 
 func (t *TransferWitness) Content() {
 	log.Println("Transfer witness btransfer = ", t.BTransfer)
 	log.Println("Transfer witness BDiff = ", t.BDiff)
 	log.Println("Transfer witness Index = ", t.Index)
-	log.Println("Transfer witness sk = ", t.SK)
+	log.Println("Transfer witness sk = <redacted>")
 	log.Println("Transfer witness R = ", t.R)
 }
 
 type BurnWitness struct {
 	SK    string // keypair['x'], bigInt hex string
 	BDiff int
+	Key   KeyHandle // replaces SK when set
 }
 
 type BurnStatement struct {
@@ -107,3 +111,102 @@ func parseBigInt2ABI_Bytes32(e *ebigint.NBigInt) ABI_Bytes32 {
 	copy(result[:], t[:])
 	return result
 }
+
+// proofReader walks a serialized proof, which is a plain concatenation of
+// points and 32 bytes scalars. Points are 64 bytes x||y, or 33 bytes in the
+// compressed form.
+type proofReader struct {
+	data      []byte
+	offset    int
+	pointSize int
+}
+
+func newProofReader(proof string) (*proofReader, error) {
+	return newProofReaderWithPointSize(proof, 64)
+}
+
+func newCompressedProofReader(proof string) (*proofReader, error) {
+	return newProofReaderWithPointSize(proof, bn256.CompressedG1Size)
+}
+
+func newProofReaderWithPointSize(proof string, pointSize int) (*proofReader, error) {
+	if len(proof) >= 2 && (proof[:2] == "0x" || proof[:2] == "0X") {
+		proof = proof[2:]
+	}
+	data, err := hex.DecodeString(proof)
+	if err != nil {
+		return nil, fmt.Errorf("invalid proof hex, err:%s", err.Error())
+	}
+	return &proofReader{data: data, pointSize: pointSize}, nil
+}
+
+func (r *proofReader) Len() int {
+	return len(r.data)
+}
+
+// size is the length of a proof with the given number of points and scalars.
+func (r *proofReader) size(points, scalars int) int {
+	return points*r.pointSize + scalars*32
+}
+
+// finish reports bytes left over after the last field was read.
+func (r *proofReader) finish() error {
+	if r.offset != len(r.data) {
+		return fmt.Errorf("%d trailing bytes at offset %d", len(r.data)-r.offset, r.offset)
+	}
+	return nil
+}
+
+func (r *proofReader) readPoint() (Point, error) {
+	if r.offset+r.pointSize > len(r.data) {
+		return Point{}, fmt.Errorf("proof truncated at offset %d", r.offset)
+	}
+	var p Point
+	var err error
+	if r.pointSize == 64 {
+		p, err = pointFromBytes(r.data[r.offset : r.offset+64])
+	} else {
+		p, err = pointFromCompressedBytes(r.data[r.offset : r.offset+r.pointSize])
+	}
+	if err != nil {
+		return Point{}, fmt.Errorf("%w at offset %d, err:%s", ErrInvalidPoint, r.offset, err.Error())
+	}
+	r.offset += r.pointSize
+	return p, nil
+}
+
+func (r *proofReader) readPoints(n int) ([]Point, error) {
+	points := make([]Point, n)
+	for i := 0; i < n; i++ {
+		p, err := r.readPoint()
+		if err != nil {
+			return nil, err
+		}
+		points[i] = p
+	}
+	return points, nil
+}
+
+func (r *proofReader) readScalar() (*ebigint.NBigInt, error) {
+	if r.offset+32 > len(r.data) {
+		return nil, fmt.Errorf("proof truncated at offset %d", r.offset)
+	}
+	s := ebigint.FromBytes(r.data[r.offset : r.offset+32])
+	if s.Cmp(b128.Q().Int) >= 0 {
+		return nil, fmt.Errorf("non-canonical scalar at offset %d", r.offset)
+	}
+	r.offset += 32
+	return s.ToRed(b128.Q()), nil
+}
+
+func (r *proofReader) readScalars(n int) ([]*ebigint.NBigInt, error) {
+	scalars := make([]*ebigint.NBigInt, n)
+	for i := 0; i < n; i++ {
+		s, err := r.readScalar()
+		if err != nil {
+			return nil, err
+		}
+		scalars[i] = s
+	}
+	return scalars, nil
+}
@@ -0,0 +1,166 @@
+package core
+
+import (
+	"encoding/hex"
+	"fmt"
+
+	"github.com/hpb-project/HCash-SDK/common/types"
+	"github.com/hpb-project/HCash-SDK/core/bn256"
+)
+
+// CompressedRepresentation is Representation in the 33 bytes compressed
+// form, the parity of y followed by x.
+func (b *BN128) CompressedRepresentation(p Point) string {
+	if p.p == nil {
+		return "0x" + hex.EncodeToString(make([]byte, bn256.CompressedG1Size))
+	}
+	return "0x" + hex.EncodeToString(new(bn256.G1).Set(p.p).MarshalCompressed())
+}
+
+// pointFromCompressedBytes decodes a 33 bytes compressed point, it is all
+// zeros for the point at infinity.
+func pointFromCompressedBytes(data []byte) (Point, error) {
+	if len(data) != bn256.CompressedG1Size {
+		return Point{}, fmt.Errorf("invalid compressed point length")
+	}
+	g := new(bn256.G1)
+	if _, err := g.UnmarshalCompressed(data); err != nil {
+		return Point{}, err
+	}
+	return Point{g}, nil
+}
+
+// CompressTransferProof converts a transfer proof for a ring of N accounts
+// to the compressed form, which is about 45% shorter.
+func CompressTransferProof(proof string, N int) (string, error) {
+	z, err := UnserializeZetherProof(proof, N)
+	if err != nil {
+		return "", err
+	}
+	return z.SerializeCompressed(), nil
+}
+
+// DecompressTransferProof converts a compressed transfer proof back to the
+// layout ZetherVerifier.sol expects.
+func DecompressTransferProof(proof string, N int) (string, error) {
+	z, err := UnserializeZetherProofCompressed(proof, N)
+	if err != nil {
+		return "", err
+	}
+	return z.Serialize(), nil
+}
+
+// CompressBurnProof converts a burn proof to the compressed form.
+func CompressBurnProof(proof string) (string, error) {
+	z, err := UnserializeBurnProof(proof)
+	if err != nil {
+		return "", err
+	}
+	return z.SerializeCompressed(), nil
+}
+
+// DecompressBurnProof converts a compressed burn proof back to the layout
+// BurnVerifier.sol expects.
+func DecompressBurnProof(proof string) (string, error) {
+	z, err := UnserializeBurnProofCompressed(proof)
+	if err != nil {
+		return "", err
+	}
+	return z.Serialize(), nil
+}
+
+func compressPoints(points []types.Point) []types.CompressedPoint {
+	var result = make([]types.CompressedPoint, len(points))
+	for i, p := range points {
+		result[i] = p.Compress()
+	}
+	return result
+}
+
+func decompressPoints(points []types.CompressedPoint) ([]types.Point, error) {
+	var result = make([]types.Point, len(points))
+	for i, c := range points {
+		p, err := c.Decompress()
+		if err != nil {
+			return nil, fmt.Errorf("point %d: %w", i, err)
+		}
+		result[i] = p
+	}
+	return result, nil
+}
+
+// CompressedTransferStatement is a TransferStatement with compressed points.
+type CompressedTransferStatement struct {
+	CLn   []types.CompressedPoint
+	CRn   []types.CompressedPoint
+	C     []types.CompressedPoint
+	D     types.CompressedPoint
+	Y     []types.CompressedPoint
+	Epoch int
+}
+
+func (t TransferStatement) Compress() CompressedTransferStatement {
+	return CompressedTransferStatement{
+		CLn:   compressPoints(t.CLn),
+		CRn:   compressPoints(t.CRn),
+		C:     compressPoints(t.C),
+		D:     t.D.Compress(),
+		Y:     compressPoints(t.Y),
+		Epoch: t.Epoch,
+	}
+}
+
+func (t CompressedTransferStatement) Decompress() (TransferStatement, error) {
+	var s = TransferStatement{Epoch: t.Epoch}
+	var err error
+	if s.CLn, err = decompressPoints(t.CLn); err != nil {
+		return TransferStatement{}, fmt.Errorf("CLn: %w", err)
+	}
+	if s.CRn, err = decompressPoints(t.CRn); err != nil {
+		return TransferStatement{}, fmt.Errorf("CRn: %w", err)
+	}
+	if s.C, err = decompressPoints(t.C); err != nil {
+		return TransferStatement{}, fmt.Errorf("C: %w", err)
+	}
+	if s.D, err = t.D.Decompress(); err != nil {
+		return TransferStatement{}, fmt.Errorf("D: %w", err)
+	}
+	if s.Y, err = decompressPoints(t.Y); err != nil {
+		return TransferStatement{}, fmt.Errorf("Y: %w", err)
+	}
+	return s, nil
+}
+
+// CompressedBurnStatement is a BurnStatement with compressed points.
+type CompressedBurnStatement struct {
+	CLn    types.CompressedPoint
+	CRn    types.CompressedPoint
+	Y      types.CompressedPoint
+	Epoch  int
+	Sender string
+}
+
+func (t BurnStatement) Compress() CompressedBurnStatement {
+	return CompressedBurnStatement{
+		CLn:    t.CLn.Compress(),
+		CRn:    t.CRn.Compress(),
+		Y:      t.Y.Compress(),
+		Epoch:  t.Epoch,
+		Sender: t.Sender,
+	}
+}
+
+func (t CompressedBurnStatement) Decompress() (BurnStatement, error) {
+	var s = BurnStatement{Epoch: t.Epoch, Sender: t.Sender}
+	var err error
+	if s.CLn, err = t.CLn.Decompress(); err != nil {
+		return BurnStatement{}, fmt.Errorf("CLn: %w", err)
+	}
+	if s.CRn, err = t.CRn.Decompress(); err != nil {
+		return BurnStatement{}, fmt.Errorf("CRn: %w", err)
+	}
+	if s.Y, err = t.Y.Decompress(); err != nil {
+		return BurnStatement{}, fmt.Errorf("Y: %w", err)
+	}
+	return s, nil
+}
@@ -0,0 +1,65 @@
+package core
+
+import (
+	"errors"
+	"testing"
+
+	"gotest.tools/assert"
+)
+
+func TestCompressTransferProof(t *testing.T) {
+	istatement, iwitness := transferVector()
+	proof, err := ProveTransfer(istatement, iwitness)
+	assert.NilError(t, err)
+
+	compressed, err := CompressTransferProof(proof, 2)
+	assert.NilError(t, err)
+	// 26 points of 33 bytes and 12 scalars.
+	assert.Equal(t, len(compressed), 2+2*(26*33+12*32))
+
+	decompressed, err := DecompressTransferProof(compressed, 2)
+	assert.NilError(t, err)
+	assert.Equal(t, decompressed, proof)
+
+	_, err = DecompressTransferProof(proof, 2)
+	assert.ErrorContains(t, err, "invalid transfer proof length")
+	// BA with an unknown tag byte.
+	_, err = DecompressTransferProof("0x04"+compressed[4:], 2)
+	assert.Assert(t, errors.Is(err, ErrInvalidPoint))
+
+	z, err := UnserializeZetherProofCompressed(compressed, 2)
+	assert.NilError(t, err)
+	ip, err := UnserializeInnerProductProofCompressed(z.GetIPProof().SerializeCompressed())
+	assert.NilError(t, err)
+	assert.Equal(t, ip.Serialize(), z.GetIPProof().Serialize())
+}
+
+func TestCompressBurnProof(t *testing.T) {
+	proof, err := ProveBurn(burnVector())
+	assert.NilError(t, err)
+
+	compressed, err := CompressBurnProof(proof)
+	assert.NilError(t, err)
+	assert.Equal(t, len(compressed), 2+2*(14*33+8*32))
+
+	decompressed, err := DecompressBurnProof(compressed)
+	assert.NilError(t, err)
+	assert.Equal(t, decompressed, proof)
+}
+
+func TestCompressStatement(t *testing.T) {
+	istatement, _ := transferVector()
+	s, err := istatement.Compress().Decompress()
+	assert.NilError(t, err)
+	assert.DeepEqual(t, s, istatement)
+
+	c := istatement.Compress()
+	c.Y[1][0] = 0x05
+	_, err = c.Decompress()
+	assert.Assert(t, errors.Is(err, ErrInvalidPoint))
+
+	bstatement, _ := burnVector()
+	b, err := bstatement.Compress().Decompress()
+	assert.NilError(t, err)
+	assert.DeepEqual(t, b, bstatement)
+}
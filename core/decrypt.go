@@ -0,0 +1,78 @@
+package core
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/hpb-project/HCash-SDK/common/types"
+)
+
+// A transfer adds C[i] = g^v_i + y_i^r to the account of every y_i of the
+// ring and D = g^r to all of them, with v = -value for the sender, value for
+// the receiver and 0 for the decoys. The owner of y_i gets g^v_i back as
+// C[i] - x*D. A sender of 0 is not told apart from a decoy.
+const (
+	TransferSender   = "sender"
+	TransferReceiver = "receiver"
+	TransferDecoy    = "decoy"
+)
+
+var ErrNotInRing = errors.New("key is not in the ring of the transfer")
+
+// DecryptedTransfer is a transfer as seen by one member of its ring.
+type DecryptedTransfer struct {
+	Index int    `json:"index"`
+	Role  string `json:"role"`
+	// Value is the amount received, or debited for the sender.
+	Value uint32 `json:"value"`
+}
+
+// DecryptTransfer decrypts the part of key in a transfer to the ring Y.
+func DecryptTransfer(C []types.Point, D types.Point, Y []types.Point, key KeyHandle) (*DecryptedTransfer, error) {
+	return DefaultBalanceTable().DecryptTransfer(C, D, Y, key)
+}
+
+// DecryptTransfer decrypts the part of key in a transfer to the ring Y.
+func (t *BalanceTable) DecryptTransfer(C []types.Point, D types.Point, Y []types.Point, key KeyHandle) (*DecryptedTransfer, error) {
+	if len(C) != len(Y) {
+		return nil, ErrRingSizeMismatch
+	}
+	y, err := key.PublicKey()
+	if err != nil {
+		return nil, err
+	}
+	var index = -1
+	for i, yi := range Y {
+		if yi == y {
+			index = i
+			break
+		}
+	}
+	if index < 0 {
+		return nil, ErrNotInRing
+	}
+
+	gv, err := key.Decrypt(C[index], D)
+	if err != nil {
+		return nil, err
+	}
+	p, err := b128.DecodePoint(gv)
+	if err != nil {
+		return nil, err
+	}
+	value, err := t.Solve(p)
+	if err == nil {
+		var result = &DecryptedTransfer{Index: index, Role: TransferReceiver, Value: value}
+		if value == 0 {
+			result.Role = TransferDecoy
+		}
+		return result, nil
+	}
+	if !errors.Is(err, ErrBalanceNotFound) {
+		return nil, err
+	}
+	if value, err = t.Solve(p.Neg()); err != nil {
+		return nil, fmt.Errorf("value of C[%d]: %w", index, err)
+	}
+	return &DecryptedTransfer{Index: index, Role: TransferSender, Value: value}, nil
+}
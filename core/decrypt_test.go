@@ -0,0 +1,67 @@
+package core
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/hpb-project/HCash-SDK/common/types"
+	"github.com/hpb-project/HCash-SDK/core/ebigint"
+	"gotest.tools/assert"
+)
+
+func TestDecryptTransfer(t *testing.T) {
+	// decoy, receiver, sender.
+	var keys = make([]*MemoryKey, 3)
+	var Y = make([]types.Point, 3)
+	x := testScalar
+	for i := range keys {
+		x = x.RedAdd(testScalar)
+		keys[i] = NewMemoryKey(x)
+		Y[i], _ = keys[i].PublicKey()
+	}
+	r := ebigint.NewNBigInt(12345).ToRed(b128.Q())
+	var values = []int64{0, 7, -7}
+	var C = make([]types.Point, 3)
+	for i, v := range values {
+		y, _ := b128.DecodePoint(Y[i])
+		C[i] = b128.Serialize(FixedG().Mul(ebigint.NewNBigInt(v).ToRed(b128.Q())).Add(y.Mul(r)))
+	}
+	D := b128.Serialize(FixedG().Mul(r))
+
+	// through the calldata of the transaction.
+	var c, y strings.Builder
+	for i := range Y {
+		c.WriteString(C[i].XY()[2:])
+		y.WriteString(Y[i].XY()[2:])
+	}
+	proof := "0x" + strings.Repeat("ab", 64)
+	calldata, err := ParseTransfer(TransferSelector + Transfer(c.String(), D.XY(), y.String(), Y[0].XY(), proof))
+	assert.NilError(t, err)
+	assert.DeepEqual(t, calldata.C, C)
+	assert.DeepEqual(t, calldata.Y, Y)
+	assert.Equal(t, calldata.D, D)
+	assert.Equal(t, calldata.U, Y[0])
+	assert.Equal(t, calldata.Proof, proof)
+
+	var want = []DecryptedTransfer{
+		{Index: 0, Role: TransferDecoy, Value: 0},
+		{Index: 1, Role: TransferReceiver, Value: 7},
+		{Index: 2, Role: TransferSender, Value: 7},
+	}
+	for i, key := range keys {
+		got, err := DecryptTransfer(calldata.C, calldata.D, calldata.Y, key)
+		assert.NilError(t, err)
+		assert.Equal(t, *got, want[i])
+	}
+
+	_, err = DecryptTransfer(C, D, Y, NewMemoryKey(testScalar))
+	assert.Assert(t, errors.Is(err, ErrNotInRing))
+	_, err = DecryptTransfer(C[:2], D, Y, keys[0])
+	assert.Assert(t, errors.Is(err, ErrRingSizeMismatch))
+
+	_, err = ParseTransfer("12345678" + Transfer(c.String(), D.XY(), y.String(), Y[0].XY(), proof))
+	assert.ErrorContains(t, err, "selector")
+	_, err = ParseTransfer(Transfer(c.String(), D.XY(), y.String(), Y[0].XY(), proof)[:7*64])
+	assert.Assert(t, err != nil)
+}
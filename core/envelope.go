@@ -0,0 +1,503 @@
+package core
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+
+	ethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/hpb-project/HCash-SDK/common/types"
+)
+
+// An envelope frames a statement, witness or proof for storage or transport:
+//
+//	magic     4  "HCSH"
+//	version   1  EnvelopeVersion
+//	type      1  EnvelopeType
+//	flags     1  envelopeChecksum | envelopeCompressed
+//	ring size 2  big-endian, 0 for the types without a ring
+//	length    4  big-endian length of the payload
+//	payload
+//	checksum  4  keccak256(header || payload)[:4], with envelopeChecksum
+//
+// Points in the payload are 64 bytes x||y, or 33 bytes with
+// envelopeCompressed. Integers are big-endian, scalars 32 bytes.
+const (
+	EnvelopeVersion = 1
+
+	envelopeMagic      = "HCSH"
+	envelopeHeaderSize = 13
+	envelopeSumSize    = 4
+
+	envelopeChecksum   = 1 << 0
+	envelopeCompressed = 1 << 1
+)
+
+type EnvelopeType uint8
+
+const (
+	EnvelopeTransferStatement EnvelopeType = iota + 1
+	EnvelopeBurnStatement
+	EnvelopeTransferWitness
+	EnvelopeBurnWitness
+	EnvelopeTransferProof
+	EnvelopeBurnProof
+)
+
+func (t EnvelopeType) String() string {
+	switch t {
+	case EnvelopeTransferStatement:
+		return "transfer statement"
+	case EnvelopeBurnStatement:
+		return "burn statement"
+	case EnvelopeTransferWitness:
+		return "transfer witness"
+	case EnvelopeBurnWitness:
+		return "burn witness"
+	case EnvelopeTransferProof:
+		return "transfer proof"
+	case EnvelopeBurnProof:
+		return "burn proof"
+	}
+	return fmt.Sprintf("unknown type %d", uint8(t))
+}
+
+// EnvelopeOptions selects the optional parts of an envelope. Compressed
+// only changes the points of statements and proofs.
+type EnvelopeOptions struct {
+	Compressed bool
+	Checksum   bool
+}
+
+// EnvelopeHeader describes an envelope without decoding its payload.
+type EnvelopeHeader struct {
+	Version    uint8
+	Type       EnvelopeType
+	RingSize   int
+	Compressed bool
+	Checksum   bool
+}
+
+// ReadEnvelopeHeader checks the framing and the checksum of data and
+// returns its header, so that a caller can dispatch on the type.
+func ReadEnvelopeHeader(data []byte) (*EnvelopeHeader, error) {
+	header, _, err := openEnvelope(data)
+	return header, err
+}
+
+func openEnvelope(data []byte) (*EnvelopeHeader, []byte, error) {
+	if len(data) < envelopeHeaderSize || string(data[:4]) != envelopeMagic {
+		return nil, nil, fmt.Errorf("%w: bad magic", ErrInvalidEnvelope)
+	}
+	if data[4] != EnvelopeVersion {
+		return nil, nil, fmt.Errorf("%w: %d", ErrEnvelopeVersion, data[4])
+	}
+	var flags = data[6]
+	if flags&^(envelopeChecksum|envelopeCompressed) != 0 {
+		return nil, nil, fmt.Errorf("%w: unknown flags %#x", ErrInvalidEnvelope, flags)
+	}
+	var header = &EnvelopeHeader{
+		Version:    data[4],
+		Type:       EnvelopeType(data[5]),
+		RingSize:   int(binary.BigEndian.Uint16(data[7:9])),
+		Compressed: flags&envelopeCompressed != 0,
+		Checksum:   flags&envelopeChecksum != 0,
+	}
+	var length = int(binary.BigEndian.Uint32(data[9:13]))
+	var want = envelopeHeaderSize + length
+	if header.Checksum {
+		want += envelopeSumSize
+	}
+	if len(data) != want {
+		return nil, nil, fmt.Errorf("%w: length %d, want %d", ErrInvalidEnvelope, len(data), want)
+	}
+	var end = envelopeHeaderSize + length
+	if header.Checksum && !bytes.Equal(crypto.Keccak256(data[:end])[:envelopeSumSize], data[end:]) {
+		return nil, nil, ErrChecksumMismatch
+	}
+	return header, data[envelopeHeaderSize:end], nil
+}
+
+func sealEnvelope(t EnvelopeType, ringSize int, opts EnvelopeOptions, payload []byte) []byte {
+	var flags byte
+	if opts.Checksum {
+		flags |= envelopeChecksum
+	}
+	if opts.Compressed {
+		flags |= envelopeCompressed
+	}
+	var data = make([]byte, envelopeHeaderSize, envelopeHeaderSize+len(payload)+envelopeSumSize)
+	copy(data, envelopeMagic)
+	data[4] = EnvelopeVersion
+	data[5] = byte(t)
+	data[6] = flags
+	binary.BigEndian.PutUint16(data[7:9], uint16(ringSize))
+	binary.BigEndian.PutUint32(data[9:13], uint32(len(payload)))
+	data = append(data, payload...)
+	if opts.Checksum {
+		data = append(data, crypto.Keccak256(data)[:envelopeSumSize]...)
+	}
+	return data
+}
+
+// openTyped opens data and checks that it holds an envelope of type t.
+func openTyped(data []byte, t EnvelopeType) (*EnvelopeHeader, *envelopeReader, error) {
+	header, payload, err := openEnvelope(data)
+	if err != nil {
+		return nil, nil, err
+	}
+	if header.Type != t {
+		return nil, nil, fmt.Errorf("%w: got %s, want %s", ErrEnvelopeType, header.Type, t)
+	}
+	return header, &envelopeReader{data: payload, compressed: header.Compressed}, nil
+}
+
+type envelopeWriter struct {
+	buf        bytes.Buffer
+	compressed bool
+}
+
+func (w *envelopeWriter) writePoint(p types.Point) {
+	if w.compressed {
+		c := p.Compress()
+		w.buf.Write(c[:])
+	} else {
+		w.buf.Write(p[:])
+	}
+}
+
+func (w *envelopeWriter) writePoints(points []types.Point) {
+	for _, p := range points {
+		w.writePoint(p)
+	}
+}
+
+func (w *envelopeWriter) writeUint(v uint64, size int) {
+	var b [8]byte
+	binary.BigEndian.PutUint64(b[:], v)
+	w.buf.Write(b[8-size:])
+}
+
+// envelopeReader reads a payload, the first error sticks and is returned by
+// finish.
+type envelopeReader struct {
+	data       []byte
+	offset     int
+	compressed bool
+	err        error
+}
+
+func (r *envelopeReader) next(n int) []byte {
+	if r.err != nil {
+		return nil
+	}
+	if r.offset+n > len(r.data) {
+		r.err = fmt.Errorf("%w: payload truncated at offset %d", ErrInvalidEnvelope, r.offset)
+		return nil
+	}
+	b := r.data[r.offset : r.offset+n]
+	r.offset += n
+	return b
+}
+
+func (r *envelopeReader) readPoint() types.Point {
+	var p types.Point
+	var err error
+	if r.compressed {
+		var c types.CompressedPoint
+		if b := r.next(len(c)); b != nil {
+			copy(c[:], b)
+			p, err = c.Decompress()
+		}
+	} else if b := r.next(len(p)); b != nil {
+		p, err = types.PointFromBytes(b)
+	}
+	if err != nil && r.err == nil {
+		r.err = fmt.Errorf("%w at offset %d", err, r.offset)
+	}
+	return p
+}
+
+func (r *envelopeReader) readPoints(n int) []types.Point {
+	var points = make([]types.Point, n)
+	for i := range points {
+		points[i] = r.readPoint()
+	}
+	return points
+}
+
+func (r *envelopeReader) readUint(size int) uint64 {
+	var b [8]byte
+	if d := r.next(size); d != nil {
+		copy(b[8-size:], d)
+	}
+	return binary.BigEndian.Uint64(b[:])
+}
+
+func (r *envelopeReader) readScalar() string {
+	if d := r.next(32); d != nil {
+		return "0x" + hex.EncodeToString(d)
+	}
+	return ""
+}
+
+func (r *envelopeReader) finish() error {
+	if r.err == nil && r.offset != len(r.data) {
+		r.err = fmt.Errorf("%w: %d trailing bytes", ErrInvalidEnvelope, len(r.data)-r.offset)
+	}
+	return r.err
+}
+
+func checkEpoch(epoch int) error {
+	if epoch < 0 {
+		return fmt.Errorf("%w: epoch %d", ErrValueOutOfRange, epoch)
+	}
+	return nil
+}
+
+// EncodeTransferStatement frames a transfer statement. The payload is CLn,
+// CRn, C and y of the ring, then D and the epoch as 8 bytes.
+func EncodeTransferStatement(statement TransferStatement, opts EnvelopeOptions) ([]byte, error) {
+	if err := checkRing(statement); err != nil {
+		return nil, err
+	}
+	if err := checkEpoch(statement.Epoch); err != nil {
+		return nil, err
+	}
+	var N = len(statement.Y)
+	if N > 1<<15 {
+		return nil, fmt.Errorf("%w: ring size %d", ErrInvalidEnvelope, N)
+	}
+	var w = &envelopeWriter{compressed: opts.Compressed}
+	w.writePoints(statement.CLn)
+	w.writePoints(statement.CRn)
+	w.writePoints(statement.C)
+	w.writePoints(statement.Y)
+	w.writePoint(statement.D)
+	w.writeUint(uint64(statement.Epoch), 8)
+	return sealEnvelope(EnvelopeTransferStatement, N, opts, w.buf.Bytes()), nil
+}
+
+func DecodeTransferStatement(data []byte) (TransferStatement, error) {
+	header, r, err := openTyped(data, EnvelopeTransferStatement)
+	if err != nil {
+		return TransferStatement{}, err
+	}
+	var N = header.RingSize
+	var statement = TransferStatement{
+		CLn: r.readPoints(N),
+		CRn: r.readPoints(N),
+		C:   r.readPoints(N),
+		Y:   r.readPoints(N),
+		D:   r.readPoint(),
+	}
+	statement.Epoch = int(r.readUint(8))
+	if err := r.finish(); err != nil {
+		return TransferStatement{}, err
+	}
+	if err := checkRing(statement); err != nil {
+		return TransferStatement{}, err
+	}
+	if err := checkEpoch(statement.Epoch); err != nil {
+		return TransferStatement{}, err
+	}
+	return statement, nil
+}
+
+// EncodeBurnStatement frames a burn statement. The payload is CLn, CRn, y,
+// the epoch as 8 bytes and the 20 bytes sender.
+func EncodeBurnStatement(statement BurnStatement, opts EnvelopeOptions) ([]byte, error) {
+	if !ethcommon.IsHexAddress(statement.Sender) {
+		return nil, fmt.Errorf("%w: sender %q", ErrInvalidEnvelope, statement.Sender)
+	}
+	if err := checkEpoch(statement.Epoch); err != nil {
+		return nil, err
+	}
+	var w = &envelopeWriter{compressed: opts.Compressed}
+	w.writePoints([]types.Point{statement.CLn, statement.CRn, statement.Y})
+	w.writeUint(uint64(statement.Epoch), 8)
+	w.buf.Write(ethcommon.HexToAddress(statement.Sender).Bytes())
+	return sealEnvelope(EnvelopeBurnStatement, 0, opts, w.buf.Bytes()), nil
+}
+
+func DecodeBurnStatement(data []byte) (BurnStatement, error) {
+	_, r, err := openTyped(data, EnvelopeBurnStatement)
+	if err != nil {
+		return BurnStatement{}, err
+	}
+	var statement = BurnStatement{
+		CLn: r.readPoint(),
+		CRn: r.readPoint(),
+		Y:   r.readPoint(),
+	}
+	statement.Epoch = int(r.readUint(8))
+	statement.Sender = ethcommon.BytesToAddress(r.next(ethcommon.AddressLength)).Hex()
+	if err := r.finish(); err != nil {
+		return BurnStatement{}, err
+	}
+	if err := checkEpoch(statement.Epoch); err != nil {
+		return BurnStatement{}, err
+	}
+	return statement, nil
+}
+
+// EncodeTransferWitness frames a transfer witness. The payload is the
+// transfer amount and the remaining balance as 4 bytes, the sender and
+// receiver index as 2 bytes, then sk and r. The ring size is not known from
+// the witness, it is 0.
+func EncodeTransferWitness(witness TransferWitness, opts EnvelopeOptions) ([]byte, error) {
+	if err := checkValue("bTransfer", witness.BTransfer); err != nil {
+		return nil, err
+	}
+	if err := checkValue("bDiff", witness.BDiff); err != nil {
+		return nil, err
+	}
+	if err := checkIndex(witness.Index, 1<<15); err != nil {
+		return nil, err
+	}
+	sk, err := decodeScalar("sk", witness.SK)
+	if err != nil {
+		return nil, err
+	}
+	r, err := decodeScalar("r", witness.R)
+	if err != nil {
+		return nil, err
+	}
+	var w = &envelopeWriter{}
+	w.writeUint(uint64(witness.BTransfer), 4)
+	w.writeUint(uint64(witness.BDiff), 4)
+	w.writeUint(uint64(witness.Index[0]), 2)
+	w.writeUint(uint64(witness.Index[1]), 2)
+	w.buf.Write(BytePadding(sk.Bytes(), 32))
+	w.buf.Write(BytePadding(r.Bytes(), 32))
+	return sealEnvelope(EnvelopeTransferWitness, 0, opts, w.buf.Bytes()), nil
+}
+
+func DecodeTransferWitness(data []byte) (TransferWitness, error) {
+	_, r, err := openTyped(data, EnvelopeTransferWitness)
+	if err != nil {
+		return TransferWitness{}, err
+	}
+	var witness TransferWitness
+	witness.BTransfer = int(r.readUint(4))
+	witness.BDiff = int(r.readUint(4))
+	witness.Index = []int{int(r.readUint(2)), int(r.readUint(2))}
+	witness.SK = r.readScalar()
+	witness.R = r.readScalar()
+	if err := r.finish(); err != nil {
+		return TransferWitness{}, err
+	}
+	if err := checkIndex(witness.Index, 1<<15); err != nil {
+		return TransferWitness{}, err
+	}
+	if _, err := decodeScalar("sk", witness.SK); err != nil {
+		return TransferWitness{}, err
+	}
+	if _, err := decodeScalar("r", witness.R); err != nil {
+		return TransferWitness{}, err
+	}
+	return witness, nil
+}
+
+// EncodeBurnWitness frames a burn witness. The payload is the remaining
+// balance as 4 bytes and sk.
+func EncodeBurnWitness(witness BurnWitness, opts EnvelopeOptions) ([]byte, error) {
+	if err := checkValue("bDiff", witness.BDiff); err != nil {
+		return nil, err
+	}
+	sk, err := decodeScalar("sk", witness.SK)
+	if err != nil {
+		return nil, err
+	}
+	var w = &envelopeWriter{}
+	w.writeUint(uint64(witness.BDiff), 4)
+	w.buf.Write(BytePadding(sk.Bytes(), 32))
+	return sealEnvelope(EnvelopeBurnWitness, 0, opts, w.buf.Bytes()), nil
+}
+
+func DecodeBurnWitness(data []byte) (BurnWitness, error) {
+	_, r, err := openTyped(data, EnvelopeBurnWitness)
+	if err != nil {
+		return BurnWitness{}, err
+	}
+	var witness BurnWitness
+	witness.BDiff = int(r.readUint(4))
+	witness.SK = r.readScalar()
+	if err := r.finish(); err != nil {
+		return BurnWitness{}, err
+	}
+	if _, err := decodeScalar("sk", witness.SK); err != nil {
+		return BurnWitness{}, err
+	}
+	return witness, nil
+}
+
+// EncodeTransferProof frames a transfer proof for a ring of N, as returned
+// by ProveTransfer. The payload is the proof in the Serialize or, with
+// Compressed, the SerializeCompressed layout.
+func EncodeTransferProof(proof string, N int, opts EnvelopeOptions) ([]byte, error) {
+	if N > 1<<15 {
+		return nil, fmt.Errorf("%w: ring size %d", ErrInvalidEnvelope, N)
+	}
+	z, err := UnserializeZetherProof(proof, N)
+	if err != nil {
+		return nil, err
+	}
+	var payload = z.Serialize()
+	if opts.Compressed {
+		payload = z.SerializeCompressed()
+	}
+	data, _ := hex.DecodeString(payload[2:])
+	return sealEnvelope(EnvelopeTransferProof, N, opts, data), nil
+}
+
+// DecodeTransferProof returns the proof in the layout ZetherVerifier.sol
+// expects and the ring size it was made for.
+func DecodeTransferProof(data []byte) (string, int, error) {
+	header, r, err := openTyped(data, EnvelopeTransferProof)
+	if err != nil {
+		return "", 0, err
+	}
+	var unserialize = UnserializeZetherProof
+	if header.Compressed {
+		unserialize = UnserializeZetherProofCompressed
+	}
+	z, err := unserialize(hex.EncodeToString(r.data), header.RingSize)
+	if err != nil {
+		return "", 0, fmt.Errorf("%w: %s", ErrInvalidEnvelope, err.Error())
+	}
+	return z.Serialize(), header.RingSize, nil
+}
+
+// EncodeBurnProof frames a burn proof as returned by ProveBurn.
+func EncodeBurnProof(proof string, opts EnvelopeOptions) ([]byte, error) {
+	z, err := UnserializeBurnProof(proof)
+	if err != nil {
+		return nil, err
+	}
+	var payload = z.Serialize()
+	if opts.Compressed {
+		payload = z.SerializeCompressed()
+	}
+	data, _ := hex.DecodeString(payload[2:])
+	return sealEnvelope(EnvelopeBurnProof, 0, opts, data), nil
+}
+
+// DecodeBurnProof returns the proof in the layout BurnVerifier.sol expects.
+func DecodeBurnProof(data []byte) (string, error) {
+	header, r, err := openTyped(data, EnvelopeBurnProof)
+	if err != nil {
+		return "", err
+	}
+	var unserialize = UnserializeBurnProof
+	if header.Compressed {
+		unserialize = UnserializeBurnProofCompressed
+	}
+	z, err := unserialize(hex.EncodeToString(r.data))
+	if err != nil {
+		return "", fmt.Errorf("%w: %s", ErrInvalidEnvelope, err.Error())
+	}
+	return z.Serialize(), nil
+}
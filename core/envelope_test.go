@@ -0,0 +1,101 @@
+package core
+
+import (
+	"errors"
+	"testing"
+
+	ethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/hpb-project/HCash-SDK/common"
+	"github.com/hpb-project/HCash-SDK/core/ebigint"
+	"gotest.tools/assert"
+)
+
+func TestEnvelopeTransfer(t *testing.T) {
+	istatement, iwitness := transferVector()
+	for _, opts := range []EnvelopeOptions{{}, {Checksum: true}, {Compressed: true, Checksum: true}} {
+		data, err := EncodeTransferStatement(istatement, opts)
+		assert.NilError(t, err)
+		header, err := ReadEnvelopeHeader(data)
+		assert.NilError(t, err)
+		assert.DeepEqual(t, *header, EnvelopeHeader{Version: EnvelopeVersion, Type: EnvelopeTransferStatement,
+			RingSize: 2, Compressed: opts.Compressed, Checksum: opts.Checksum})
+		s, err := DecodeTransferStatement(data)
+		assert.NilError(t, err)
+		assert.DeepEqual(t, s, istatement)
+	}
+
+	data, err := EncodeTransferWitness(iwitness, EnvelopeOptions{Checksum: true})
+	assert.NilError(t, err)
+	w, err := DecodeTransferWitness(data)
+	assert.NilError(t, err)
+	assert.Equal(t, w.BTransfer, iwitness.BTransfer)
+	assert.Equal(t, w.BDiff, iwitness.BDiff)
+	assert.DeepEqual(t, w.Index, iwitness.Index)
+	assert.Equal(t, w.SK, b128.Bytes(ebigint.FromBytes(common.FromHex(iwitness.SK)).Int))
+
+	proof, err := ProveTransfer(istatement, iwitness)
+	assert.NilError(t, err)
+	data, err = EncodeTransferProof(proof, 2, EnvelopeOptions{Compressed: true})
+	assert.NilError(t, err)
+	p, N, err := DecodeTransferProof(data)
+	assert.NilError(t, err)
+	assert.Equal(t, N, 2)
+	assert.Equal(t, p, proof)
+}
+
+func TestEnvelopeBurn(t *testing.T) {
+	statement, witness := burnVector()
+	data, err := EncodeBurnStatement(statement, EnvelopeOptions{Compressed: true})
+	assert.NilError(t, err)
+	s, err := DecodeBurnStatement(data)
+	assert.NilError(t, err)
+	assert.Equal(t, ethcommon.HexToAddress(s.Sender), ethcommon.HexToAddress(statement.Sender))
+	s.Sender = statement.Sender
+	assert.DeepEqual(t, s, statement)
+
+	data, err = EncodeBurnWitness(witness, EnvelopeOptions{})
+	assert.NilError(t, err)
+	w, err := DecodeBurnWitness(data)
+	assert.NilError(t, err)
+	assert.Equal(t, w.BDiff, witness.BDiff)
+	assert.Equal(t, w.SK, witness.SK)
+
+	proof, err := ProveBurn(statement, witness)
+	assert.NilError(t, err)
+	data, err = EncodeBurnProof(proof, EnvelopeOptions{Checksum: true})
+	assert.NilError(t, err)
+	p, err := DecodeBurnProof(data)
+	assert.NilError(t, err)
+	assert.Equal(t, p, proof)
+}
+
+func TestEnvelopeInvalid(t *testing.T) {
+	istatement, _ := transferVector()
+	data, err := EncodeTransferStatement(istatement, EnvelopeOptions{Checksum: true})
+	assert.NilError(t, err)
+
+	corrupt := func(i int, b byte) []byte {
+		c := append([]byte{}, data...)
+		c[i] = b
+		return c
+	}
+	_, err = DecodeTransferStatement(corrupt(0, 'X'))
+	assert.Assert(t, errors.Is(err, ErrInvalidEnvelope))
+	_, err = DecodeTransferStatement(corrupt(4, 2))
+	assert.Assert(t, errors.Is(err, ErrEnvelopeVersion))
+	_, err = DecodeTransferStatement(corrupt(20, data[20]^1))
+	assert.Assert(t, errors.Is(err, ErrChecksumMismatch))
+	_, err = DecodeTransferStatement(data[:len(data)-1])
+	assert.Assert(t, errors.Is(err, ErrInvalidEnvelope))
+	_, err = DecodeBurnStatement(data)
+	assert.Assert(t, errors.Is(err, ErrEnvelopeType))
+
+	// without the checksum a flipped bit is caught by the point checks.
+	data, _ = EncodeTransferStatement(istatement, EnvelopeOptions{})
+	data[20] ^= 1
+	_, err = DecodeTransferStatement(data)
+	assert.Assert(t, errors.Is(err, ErrInvalidPoint))
+
+	_, err = EncodeTransferStatement(TransferStatement{}, EnvelopeOptions{})
+	assert.Assert(t, errors.Is(err, ErrRingSizeNotPowerOfTwo))
+}
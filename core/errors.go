@@ -0,0 +1,35 @@
+package core
+
+import (
+	"errors"
+
+	"github.com/hpb-project/HCash-SDK/common/types"
+)
+
+// Validation errors of the proving APIs. They are wrapped with the offending
+// field, test for them with errors.Is.
+var (
+	ErrRingSizeNotPowerOfTwo = errors.New("ring size is not a power of two")
+	ErrRingSizeMismatch      = errors.New("statement vectors differ in length")
+	ErrRingSizeTooLarge      = errors.New("ring size exceeds the generators")
+	ErrInvalidIndex          = errors.New("invalid sender or receiver index")
+	ErrParityMismatch        = errors.New("sender and receiver index have the same parity")
+	ErrInsufficientBalance   = errors.New("insufficient balance")
+	ErrValueOutOfRange       = errors.New("value out of range [0, B_MAX]")
+	ErrInvalidPoint          = types.ErrInvalidPoint
+	ErrInvalidScalar         = errors.New("invalid scalar")
+
+	// the witness is well formed but does not open the statement.
+	ErrKeyMismatch        = errors.New("y != g^sk")
+	ErrBalanceMismatch    = errors.New("CLn - sk*CRn != g^bDiff")
+	ErrCommitmentMismatch = errors.New("C, D do not commit to the transfer with r")
+	ErrNonceMismatch      = errors.New("u != gEpoch^sk")
+
+	ErrInvalidSignature = errors.New("invalid signature")
+
+	// envelope framing, see EncodeTransferStatement and friends.
+	ErrInvalidEnvelope  = errors.New("invalid envelope")
+	ErrEnvelopeVersion  = errors.New("unsupported envelope version")
+	ErrEnvelopeType     = errors.New("unexpected envelope type")
+	ErrChecksumMismatch = errors.New("envelope checksum mismatch")
+)
@@ -0,0 +1,84 @@
+package core
+
+import (
+	"crypto/ecdsa"
+	"crypto/hmac"
+	"crypto/sha512"
+	"fmt"
+	"math/big"
+
+	ethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/hpb-project/HCash-SDK/core/ebigint"
+)
+
+// A Zether account can be derived from an Ethereum key, so that the key
+// that pays the gas also recovers the private balance. The key signs
+// EthKeyMessage with EIP-191 personal_sign and
+//
+//	x = parse512(HMAC-SHA512("HCash Zether account from Ethereum signature v1", r || s)) mod q
+//
+// with the low s form of the signature. Signing is deterministic (RFC 6979)
+// in go-ethereum and the common wallets, so the private key and a wallet
+// signature give the same account. v is left out, wallets encode it as 0/1
+// or 27/28.
+const (
+	EthKeyMessage = "HCash Zether account\n\nSigning this message derives your private HCash account. Only sign it in HCash applications you trust."
+
+	ethKeyDomain = "HCash Zether account from Ethereum signature v1"
+)
+
+var secp256k1HalfN = new(big.Int).Rsh(crypto.S256().Params().N, 1)
+
+// EthKeyMessageHash is the EIP-191 hash of EthKeyMessage,
+// keccak256("\x19Ethereum Signed Message:\n" || len || message).
+func EthKeyMessageHash() []byte {
+	prefix := fmt.Sprintf("\x19Ethereum Signed Message:\n%d", len(EthKeyMessage))
+	return crypto.Keccak256([]byte(prefix), []byte(EthKeyMessage))
+}
+
+// CreateAccountFromEthKey derives the Zether account of an Ethereum private
+// key, it signs EthKeyMessage and calls CreateAccountFromEthSignature.
+func CreateAccountFromEthKey(priv *ecdsa.PrivateKey) (Account, error) {
+	sig, err := crypto.Sign(EthKeyMessageHash(), priv)
+	if err != nil {
+		return Account{}, err
+	}
+	return CreateAccountFromEthSignature(sig, crypto.PubkeyToAddress(priv.PublicKey))
+}
+
+// CreateAccountFromEthSignature derives the Zether account from the 65
+// bytes r || s || v personal_sign signature of EthKeyMessage by address.
+func CreateAccountFromEthSignature(sig []byte, address ethcommon.Address) (Account, error) {
+	if len(sig) != crypto.SignatureLength {
+		return Account{}, fmt.Errorf("%w: want %d bytes, got %d", ErrInvalidSignature, crypto.SignatureLength, len(sig))
+	}
+	var rs = make([]byte, 64)
+	copy(rs, sig[:64])
+	var v = sig[64]
+	if v >= 27 {
+		v -= 27
+	}
+	// the high s twin of a signature verifies too, both give one account.
+	s := new(big.Int).SetBytes(rs[32:])
+	if s.Cmp(secp256k1HalfN) > 0 {
+		s.Sub(crypto.S256().Params().N, s)
+		s.FillBytes(rs[32:])
+		v ^= 1
+	}
+	pub, err := crypto.SigToPub(EthKeyMessageHash(), append(append([]byte{}, rs...), v))
+	if err != nil {
+		return Account{}, fmt.Errorf("%w: %s", ErrInvalidSignature, err.Error())
+	}
+	if crypto.PubkeyToAddress(*pub) != address {
+		return Account{}, fmt.Errorf("%w: not signed by %s", ErrInvalidSignature, address.Hex())
+	}
+
+	mac := hmac.New(sha512.New, []byte(ethKeyDomain))
+	mac.Write(rs)
+	x := new(big.Int).Mod(new(big.Int).SetBytes(mac.Sum(nil)), b128.Q().Int)
+	if x.Sign() == 0 {
+		return Account{}, fmt.Errorf("%w: x is zero", ErrInvalidScalar)
+	}
+	return CreateAccountWithX(ebigint.ToNBigInt(x).ForceRed(b128.Q())), nil
+}
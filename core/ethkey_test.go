@@ -0,0 +1,44 @@
+package core
+
+import (
+	"encoding/hex"
+	"errors"
+	"math/big"
+	"testing"
+
+	ethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"gotest.tools/assert"
+)
+
+func TestCreateAccountFromEthKey(t *testing.T) {
+	priv, err := crypto.HexToECDSA("4c0883a69102937d6231471b5dbb6204fe5129617082792ae468d01a3f362318")
+	assert.NilError(t, err)
+	address := ethcommon.HexToAddress("0x2c7536E3605D9C16a7a3D7b1898e529396a65c23")
+	assert.Equal(t, hex.EncodeToString(EthKeyMessageHash()), "479387997fc7139cefc79f40578e5b0cd420e428d62c72e4a213972eb7c061ec")
+
+	account, err := CreateAccountFromEthKey(priv)
+	assert.NilError(t, err)
+	assert.Equal(t, b128.Bytes(account.X.Int), "0x02710e055c45c34d6bb9da844e1a8c1d435493ae9ed97c8efb829835ca6e4162")
+	assert.Equal(t, account.Y, CreateAccountWithX(account.X).Y)
+
+	// the personal_sign signature of a wallet, v as 27/28.
+	sig, _ := hex.DecodeString("6ce477bb28d6fc0549874c8679d1a2345aeffceb693b72f7227b6a9f5e58539a3c0f70a213b67d9566a93fa0e99abec2f938867f86053f7bbe6f38cfd2aa51a21c")
+	fromSig, err := CreateAccountFromEthSignature(sig, address)
+	assert.NilError(t, err)
+	assert.Equal(t, fromSig.String(), account.String())
+
+	// the high s twin.
+	high := append([]byte{}, sig...)
+	s := new(big.Int).Sub(crypto.S256().Params().N, new(big.Int).SetBytes(sig[32:64]))
+	s.FillBytes(high[32:64])
+	high[64] = 27
+	fromSig, err = CreateAccountFromEthSignature(high, address)
+	assert.NilError(t, err)
+	assert.Equal(t, fromSig.String(), account.String())
+
+	_, err = CreateAccountFromEthSignature(sig, ethcommon.HexToAddress("0xd80ac1fb177c0b8d9c66de2b9657dd57084a2d7f"))
+	assert.Assert(t, errors.Is(err, ErrInvalidSignature))
+	_, err = CreateAccountFromEthSignature(sig[:64], address)
+	assert.Assert(t, errors.Is(err, ErrInvalidSignature))
+}
@@ -0,0 +1,240 @@
+package core
+
+import (
+	"fmt"
+	"math/big"
+	"sync"
+
+	"github.com/hpb-project/HCash-SDK/core/bn256"
+	"github.com/hpb-project/HCash-SDK/core/ebigint"
+)
+
+// maxFFTLog is the 2-adicity of q, unity is a 2^28-th root of unity.
+const maxFFTLog = 28
+
+type Convolver struct {
+	unity *ebigint.NBigInt
+}
+
+func NewConvolver() *Convolver {
+	c := &Convolver{}
+
+	unity, _ := big.NewInt(0).SetString("14a3074b02521e3b1ed9852e5028452693e87be4e910500c7ba9bbddb2f46edd", 16)
+	c.unity = ebigint.ToNBigInt(unity).ToRed(b128.Q())
+
+	return c
+}
+
+// twiddles holds the powers of the n-th root of unity an FFT of size n
+// needs, they are computed once per size.
+type twiddles struct {
+	forward []*ebigint.NBigInt // omega^j for j < n/2
+	inverse []*ebigint.NBigInt // omega^-j for j < n/2
+	nInv    *ebigint.NBigInt   // 1/n
+}
+
+var (
+	twiddleLock  sync.Mutex
+	twiddleCache = make(map[int]*twiddles)
+)
+
+func checkFFTSize(n int) error {
+	if n < 1 || n&(n-1) != 0 || n > 1<<maxFFTLog {
+		return fmt.Errorf("fft input size %d is not a power of 2 up to 2^%d", n, maxFFTLog)
+	}
+	return nil
+}
+
+func (c *Convolver) twiddles(n int) *twiddles {
+	twiddleLock.Lock()
+	defer twiddleLock.Unlock()
+	if tw, ok := twiddleCache[n]; ok {
+		return tw
+	}
+
+	exp := new(big.Int).Div(new(big.Int).Lsh(big.NewInt(1), maxFFTLog), big.NewInt(int64(n)))
+	omega := c.unity.RedExp(exp)
+	omegaInv := omega.RedInvm()
+
+	tw := &twiddles{
+		forward: make([]*ebigint.NBigInt, n/2),
+		inverse: make([]*ebigint.NBigInt, n/2),
+		nInv:    ebigint.NewNBigInt(int64(n)).ToRed(b128.Q()).RedInvm(),
+	}
+	var one = ebigint.NewNBigInt(1).ToRed(b128.Q())
+	for j := range tw.forward {
+		if j == 0 {
+			tw.forward[j], tw.inverse[j] = one, one
+			continue
+		}
+		tw.forward[j] = tw.forward[j-1].RedMul(omega)
+		tw.inverse[j] = tw.inverse[j-1].RedMul(omegaInv)
+	}
+	twiddleCache[n] = tw
+	return tw
+}
+
+// bitReverse returns the bit reversal of i over log2(n) bits.
+func bitReverse(i, n int) int {
+	var r = 0
+	for n > 1 {
+		r = r<<1 | i&1
+		i >>= 1
+		n >>= 1
+	}
+	return r
+}
+
+// fftScalars transforms a in place, without the 1/n of the inverse.
+func fftScalars(a []*ebigint.NBigInt, tw *twiddles, inverse bool) {
+	var n = len(a)
+	for i := 0; i < n; i++ {
+		if j := bitReverse(i, n); i < j {
+			a[i], a[j] = a[j], a[i]
+		}
+	}
+	var w = tw.forward
+	if inverse {
+		w = tw.inverse
+	}
+	for size := 2; size <= n; size <<= 1 {
+		half, step := size/2, n/size
+		for start := 0; start < n; start += size {
+			for j := 0; j < half; j++ {
+				t := w[j*step].RedMul(a[start+j+half])
+				u := a[start+j]
+				a[start+j] = t.RedAdd(u)
+				a[start+j+half] = t.RedNeg().RedAdd(u)
+			}
+		}
+	}
+}
+
+// fftPoints transforms a in place, without the 1/n of the inverse.
+func fftPoints(a []Point, tw *twiddles, inverse bool) {
+	var n = len(a)
+	for i := 0; i < n; i++ {
+		if j := bitReverse(i, n); i < j {
+			a[i], a[j] = a[j], a[i]
+		}
+	}
+	var w = tw.forward
+	if inverse {
+		w = tw.inverse
+	}
+	for size := 2; size <= n; size <<= 1 {
+		half, step := size/2, n/size
+		for start := 0; start < n; start += size {
+			for j := 0; j < half; j++ {
+				t := a[start+j+half]
+				if j != 0 {
+					t = t.Mul(w[j*step])
+				}
+				u := a[start+j]
+				a[start+j] = u.Add(t)
+				a[start+j+half] = u.Add(t.Neg())
+			}
+		}
+	}
+}
+
+// scalePoints multiplies every point by s and normalizes the results with
+// one shared inversion. Without s the points of a may still be the caller's,
+// so they are copied before the normalization.
+func scalePoints(a []Point, s *ebigint.NBigInt) *GeneratorVector {
+	var gs = make([]*bn256.G1, len(a))
+	for i := range a {
+		if s != nil {
+			a[i] = a[i].Mul(s)
+		} else {
+			a[i] = newPoint(new(bn256.G1).Set(a[i].p))
+		}
+		gs[i] = a[i].p
+	}
+	bn256.NormalizeG1(gs)
+	return NewGeneratorVector(a)
+}
+
+func (c *Convolver) FFT_Scalar(input *FieldVector, inverse bool) (*FieldVector, error) {
+	var n = input.Length()
+	if err := checkFFTSize(n); err != nil {
+		return nil, err
+	}
+	var a = make([]*ebigint.NBigInt, n)
+	copy(a, input.GetVector())
+
+	var tw = c.twiddles(n)
+	fftScalars(a, tw, inverse)
+	if inverse {
+		for i := range a {
+			a[i] = tw.nInv.RedMul(a[i])
+		}
+	}
+	return NewFieldVector(a), nil
+}
+
+func (c *Convolver) FFT_Point(input *GeneratorVector, inverse bool) (*GeneratorVector, error) {
+	var n = input.Length()
+	if err := checkFFTSize(n); err != nil {
+		return nil, err
+	}
+	var a = make([]Point, n)
+	copy(a, input.GetVector())
+
+	var tw = c.twiddles(n)
+	fftPoints(a, tw, inverse)
+	if inverse {
+		return scalePoints(a, tw.nInv), nil
+	}
+	return scalePoints(a, nil), nil
+}
+
+func checkConvolution(exponent, base int) error {
+	if err := checkFFTSize(base); err != nil {
+		return err
+	}
+	if base < 2 || exponent != base {
+		return fmt.Errorf("convolution of %d exponents with %d bases", exponent, base)
+	}
+	return nil
+}
+
+func (c *Convolver) Convolution_Scalar(exponent *FieldVector, base *FieldVector) (*FieldVector, error) {
+	size := base.Length()
+	if err := checkConvolution(exponent.Length(), size); err != nil {
+		return nil, err
+	}
+	baseHat, _ := c.FFT_Scalar(base, false)
+	exponentHat, _ := c.FFT_Scalar(exponent.Flip(), false)
+	temp := baseHat.Hadamard(exponentHat).GetVector()
+
+	// fold the halves, the 1/2 of that goes into the 1/(size/2) of the inverse.
+	var a = make([]*ebigint.NBigInt, size/2)
+	for i := range a {
+		a[i] = temp[i].RedAdd(temp[i+size/2])
+	}
+	fftScalars(a, c.twiddles(size/2), true)
+	var scale = c.twiddles(size).nInv
+	for i := range a {
+		a[i] = scale.RedMul(a[i])
+	}
+	return NewFieldVector(a), nil
+}
+
+func (c *Convolver) Convolution_Point(exponent *FieldVector, base *GeneratorVector) (*GeneratorVector, error) {
+	size := base.Length()
+	if err := checkConvolution(exponent.Length(), size); err != nil {
+		return nil, err
+	}
+	baseHat, _ := c.FFT_Point(base, false)
+	exponentHat, _ := c.FFT_Scalar(exponent.Flip(), false)
+	temp := baseHat.Hadamard(exponentHat).GetVector()
+
+	// fold the halves, the 1/2 of that goes into the 1/(size/2) of the inverse.
+	var a = make([]Point, size/2)
+	for i := range a {
+		a[i] = temp[i].Add(temp[i+size/2])
+	}
+	fftPoints(a, c.twiddles(size/2), true)
+	return scalePoints(a, c.twiddles(size).nInv), nil
+}
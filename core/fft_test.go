@@ -0,0 +1,101 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/hpb-project/HCash-SDK/core/ebigint"
+	"gotest.tools/assert"
+)
+
+func testScalars(n int, seed int64) []*ebigint.NBigInt {
+	var v = make([]*ebigint.NBigInt, n)
+	for i := range v {
+		v[i] = Hash(b128.Bytes(ebigint.NewNBigInt(seed*1000 + int64(i)).Int))
+	}
+	return v
+}
+
+func TestFFTScalar(t *testing.T) {
+	var c = NewConvolver()
+	for _, n := range []int{1, 2, 8, 64} {
+		input := NewFieldVector(testScalars(n, 1))
+		out, err := c.FFT_Scalar(input, false)
+		assert.NilError(t, err)
+
+		// naive DFT with the same root of unity.
+		omega := c.unity.RedExp(ebigint.NewNBigInt(int64(1 << maxFFTLog / n)).Int)
+		for k := 0; k < n; k++ {
+			sum := ebigint.NewNBigInt(0).ToRed(b128.Q())
+			wk := omega.RedExp(ebigint.NewNBigInt(int64(k)).Int)
+			w := ebigint.NewNBigInt(1).ToRed(b128.Q())
+			for j := 0; j < n; j++ {
+				sum = sum.RedAdd(w.RedMul(input.GetVector()[j]))
+				w = w.RedMul(wk)
+			}
+			assert.Assert(t, out.GetVector()[k].Eq(sum), "n = %d, k = %d", n, k)
+		}
+
+		back, err := c.FFT_Scalar(out, true)
+		assert.NilError(t, err)
+		for i := range back.GetVector() {
+			assert.Assert(t, back.GetVector()[i].Eq(input.GetVector()[i]))
+		}
+	}
+
+	_, err := c.FFT_Scalar(NewFieldVector(testScalars(6, 1)), false)
+	assert.ErrorContains(t, err, "not a power of 2")
+	_, err = c.FFT_Scalar(NewFieldVector(nil), false)
+	assert.ErrorContains(t, err, "not a power of 2")
+}
+
+func TestConvolution(t *testing.T) {
+	var c = NewConvolver()
+	const n = 16
+	exponent := NewFieldVector(testScalars(n, 2))
+	base := testScalars(n, 3)
+	var points = make([]Point, n)
+	for i, b := range base {
+		points[i] = FixedG().Mul(b)
+	}
+
+	scalars, err := c.Convolution_Scalar(exponent, NewFieldVector(base))
+	assert.NilError(t, err)
+	conv, err := c.Convolution_Point(exponent, NewGeneratorVector(points))
+	assert.NilError(t, err)
+	assert.Equal(t, conv.Length(), n/2)
+
+	// result[j] = sum base[i] * exponent[i - 2j].
+	for j := 0; j < n/2; j++ {
+		sum := ebigint.NewNBigInt(0).ToRed(b128.Q())
+		for i := 0; i < n; i++ {
+			sum = sum.RedAdd(base[i].RedMul(exponent.GetVector()[((i-2*j)%n+n)%n]))
+		}
+		assert.Assert(t, scalars.GetVector()[j].Eq(sum), "j = %d", j)
+		assert.Assert(t, conv.GetVector()[j].Equal(FixedG().Mul(sum)), "j = %d", j)
+	}
+
+	// the input points are left alone, also on the n = 1 path.
+	one, err := c.FFT_Point(NewGeneratorVector(points[:1]), false)
+	assert.NilError(t, err)
+	assert.Assert(t, one.GetVector()[0].p != points[0].p)
+	assert.Assert(t, one.GetVector()[0].Equal(points[0]))
+
+	_, err = c.Convolution_Point(NewFieldVector(testScalars(8, 2)), NewGeneratorVector(points))
+	assert.ErrorContains(t, err, "convolution of 8 exponents with 16 bases")
+	_, err = c.Convolution_Point(NewFieldVector(testScalars(3, 2)), NewGeneratorVector(points[:3]))
+	assert.ErrorContains(t, err, "not a power of 2")
+}
+
+func BenchmarkConvolutionPoint64(b *testing.B) {
+	var c = NewConvolver()
+	exponent := NewFieldVector(testScalars(64, 2))
+	var points = make([]Point, 64)
+	for i, s := range testScalars(64, 3) {
+		points[i] = FixedG().Mul(s)
+	}
+	base := NewGeneratorVector(points)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		c.Convolution_Point(exponent, base)
+	}
+}
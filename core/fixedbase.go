@@ -0,0 +1,105 @@
+package core
+
+import (
+	"container/list"
+	"encoding/hex"
+	"math/big"
+	"sync"
+
+	"github.com/hpb-project/HCash-SDK/core/bn256"
+	"github.com/hpb-project/HCash-SDK/core/ebigint"
+	solsha3 "github.com/miguelmota/go-solidity-sha3"
+)
+
+// FixedBasePoint is a point with a precomputed multiplication table, for
+// the bases that are multiplied over and over: g, h and the epoch bases.
+// The zero value of table falls back to Point.Mul.
+type FixedBasePoint struct {
+	point Point
+	table *bn256.G1Table
+}
+
+func NewFixedBasePoint(p Point) *FixedBasePoint {
+	return &FixedBasePoint{
+		point: p,
+		table: bn256.NewG1Table(p.p),
+	}
+}
+
+func (f *FixedBasePoint) Point() Point {
+	return f.point
+}
+
+func (f *FixedBasePoint) Mul(o *ebigint.NBigInt) Point {
+	if f.table == nil {
+		return f.point.Mul(o)
+	}
+	return newPoint(new(bn256.G1).ScalarMultTable(f.table, o.Int))
+}
+
+var (
+	fixedG, fixedH         *FixedBasePoint
+	fixedGOnce, fixedHOnce sync.Once
+)
+
+// FixedG returns the table of g, CurveG().
+func FixedG() *FixedBasePoint {
+	fixedGOnce.Do(func() {
+		fixedG = NewFixedBasePoint(b128.CurveG())
+	})
+	return fixedG
+}
+
+// FixedH returns the table of h, mapInto("H").
+func FixedH() *FixedBasePoint {
+	fixedHOnce.Do(func() {
+		fixedH = NewFixedBasePoint(tablePoint(generatorH))
+	})
+	return fixedH
+}
+
+// gEpochCacheSize bounds the GEpoch cache, a wallet only ever touches the
+// current epoch and a few around it.
+const gEpochCacheSize = 8
+
+type gEpochEntry struct {
+	epoch int
+	point Point
+}
+
+// gEpochCache is a small LRU of the MapInto("Zether", epoch) points.
+type gEpochCache struct {
+	lock    sync.Mutex
+	entries map[int]*list.Element
+	order   *list.List
+}
+
+var gEpochs = &gEpochCache{
+	entries: make(map[int]*list.Element),
+	order:   list.New(),
+}
+
+func (c *gEpochCache) get(epoch int) Point {
+	c.lock.Lock()
+	if e, ok := c.entries[epoch]; ok {
+		c.order.MoveToFront(e)
+		c.lock.Unlock()
+		return e.Value.(*gEpochEntry).point
+	}
+	c.lock.Unlock()
+
+	hash := solsha3.SoliditySHA3(solsha3.String("Zether"), solsha3.Uint256(big.NewInt(int64(epoch))))
+	p := MapInto("0x" + hex.EncodeToString(hash))
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if _, ok := c.entries[epoch]; !ok {
+		c.entries[epoch] = c.order.PushFront(&gEpochEntry{epoch: epoch, point: p})
+		if c.order.Len() > gEpochCacheSize {
+			last := c.order.Back()
+			c.order.Remove(last)
+			delete(c.entries, last.Value.(*gEpochEntry).epoch)
+		}
+	}
+	return p
+}
@@ -0,0 +1,50 @@
+package core
+
+import (
+	"encoding/hex"
+	"math/big"
+	"testing"
+
+	"github.com/hpb-project/HCash-SDK/core/ebigint"
+	solsha3 "github.com/miguelmota/go-solidity-sha3"
+	"gotest.tools/assert"
+)
+
+func TestFixedBasePoint(t *testing.T) {
+	var scalars = []*ebigint.NBigInt{
+		ebigint.NewNBigInt(0),
+		ebigint.NewNBigInt(1),
+		ebigint.NewNBigInt(-99),
+		ebigint.FromHex("20a89bb465e9e2262e25901525509686f6a26b2fba976f1d9ff00a0cdbb362b0").ToRed(b128.Q()),
+	}
+	for _, k := range scalars {
+		assert.Assert(t, FixedG().Mul(k).Equal(b128.CurveG().Mul(k)))
+		assert.Assert(t, FixedH().Mul(k).Equal(GetGeneratorParams(64).GetH().Mul(k)))
+	}
+
+	// without a table it is a plain Mul.
+	p := GEpoch(53687137)
+	base := &FixedBasePoint{point: p}
+	assert.Assert(t, base.Mul(scalars[3]).Equal(NewFixedBasePoint(p).Mul(scalars[3])))
+}
+
+func TestGEpochCache(t *testing.T) {
+	for epoch := 100; epoch < 100+2*gEpochCacheSize; epoch++ {
+		hash := solsha3.SoliditySHA3(solsha3.String("Zether"), solsha3.Uint256(big.NewInt(int64(epoch))))
+		want := MapInto("0x" + hex.EncodeToString(hash))
+		assert.Assert(t, GEpoch(epoch).Equal(want))
+		assert.Assert(t, GEpoch(epoch).Equal(want))
+	}
+	assert.Equal(t, gEpochs.order.Len(), gEpochCacheSize)
+	_, ok := gEpochs.entries[100]
+	assert.Assert(t, !ok)
+}
+
+func BenchmarkFixedG(b *testing.B) {
+	k := ebigint.FromHex("20a89bb465e9e2262e25901525509686f6a26b2fba976f1d9ff00a0cdbb362b0").ToRed(b128.Q())
+	FixedG()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		FixedG().Mul(k)
+	}
+}
@@ -0,0 +1,76 @@
+package core
+
+import (
+	"encoding/hex"
+	"fmt"
+	"sync"
+
+	"github.com/hpb-project/HCash-SDK/common/types"
+	solsha3 "github.com/miguelmota/go-solidity-sha3"
+)
+
+var (
+	generatorParamsLock  sync.Mutex
+	generatorParamsCache = make(map[int]*GeneratorParams)
+)
+
+// GetGeneratorParams returns the process wide parameters with size gs/hs
+// generators. Up to 64 they come from the contract constants, larger sizes
+// are computed with MapInto once. The result is shared and must not be
+// modified.
+func GetGeneratorParams(size int) *GeneratorParams {
+	generatorParamsLock.Lock()
+	defer generatorParamsLock.Unlock()
+
+	if params, ok := generatorParamsCache[size]; ok {
+		return params
+	}
+	var params *GeneratorParams
+	if size <= len(generatorGS) {
+		gs := make([]Point, size)
+		hs := make([]Point, size)
+		for i := 0; i < size; i++ {
+			gs[i] = tablePoint(generatorGS[i])
+			hs[i] = tablePoint(generatorHS[i])
+		}
+		params = NewGeneratorParams(tablePoint(generatorH), NewGeneratorVector(gs), NewGeneratorVector(hs))
+		params.fixedH = FixedH()
+	} else {
+		params = NewGeneratorParams(size, nil, nil)
+	}
+	generatorParamsCache[size] = params
+	return params
+}
+
+func tablePoint(xy [2]string) Point {
+	return b128.UnSerialize(types.MustPoint(xy[0], xy[1]))
+}
+
+// CheckGeneratorTables recomputes every tabled generator with MapInto and
+// reports the first one that differs.
+func CheckGeneratorTables() error {
+	check := func(name string, want [2]string, seed []byte) error {
+		p := MapInto(hex.EncodeToString(seed))
+		if !p.Equal(tablePoint(want)) {
+			return fmt.Errorf("generator %s mismatch, table %v, MapInto %s", name, want, p.String())
+		}
+		return nil
+	}
+	if err := check("g", generatorG, solsha3.SoliditySHA3(solsha3.String("G"))); err != nil {
+		return err
+	}
+	if err := check("h", generatorH, solsha3.SoliditySHA3(solsha3.String("H"))); err != nil {
+		return err
+	}
+	for i := range generatorGS {
+		seed := solsha3.SoliditySHA3(solsha3.String("G"), solsha3.Int256(i))
+		if err := check(fmt.Sprintf("gs[%d]", i), generatorGS[i], seed); err != nil {
+			return err
+		}
+		seed = solsha3.SoliditySHA3(solsha3.String("H"), solsha3.Int256(i))
+		if err := check(fmt.Sprintf("hs[%d]", i), generatorHS[i], seed); err != nil {
+			return err
+		}
+	}
+	return nil
+}
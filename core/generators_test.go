@@ -0,0 +1,38 @@
+package core
+
+import (
+	"sync"
+	"testing"
+
+	"gotest.tools/assert"
+)
+
+func TestGeneratorTables(t *testing.T) {
+	assert.NilError(t, CheckGeneratorTables())
+
+	params := GetGeneratorParams(32)
+	assert.Equal(t, params, GetGeneratorParams(32))
+	computed := NewGeneratorParams(32, nil, nil)
+	assert.Assert(t, params.GetG().Equal(computed.GetG()))
+	assert.Assert(t, params.GetH().Equal(computed.GetH()))
+	for i := 0; i < 32; i++ {
+		assert.Assert(t, params.GetGS().GetVector()[i].Equal(computed.GetGS().GetVector()[i]))
+		assert.Assert(t, params.GetHS().GetVector()[i].Equal(computed.GetHS().GetVector()[i]))
+	}
+}
+
+func TestGetGeneratorParamsConcurrent(t *testing.T) {
+	var wg sync.WaitGroup
+	var results = make([]*GeneratorParams, 8)
+	for i := range results {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			results[i] = GetGeneratorParams(64)
+		}(i)
+	}
+	wg.Wait()
+	for _, r := range results {
+		assert.Equal(t, r, results[0])
+	}
+}
@@ -2,6 +2,10 @@ package core
 
 import (
 	"encoding/hex"
+	"errors"
+	"fmt"
+	"math/big"
+
 	"github.com/ethereum/go-ethereum/accounts/abi"
 	"github.com/hpb-project/HCash-SDK/core/ebigint"
 )
@@ -14,12 +18,20 @@ type InnerProductProof struct {
 }
 
 func (i *InnerProductProof) Serialize() string {
+	return i.serialize(b128.Representation)
+}
+
+func (i *InnerProductProof) SerializeCompressed() string {
+	return i.serialize(b128.CompressedRepresentation)
+}
+
+func (i *InnerProductProof) serialize(point func(Point) string) string {
 	var result = "0x"
 	for _, l := range i.L {
-		result += b128.Representation(l)[2:]
+		result += point(l)[2:]
 	}
 	for _, r := range i.R {
-		result += b128.Representation(r)[2:]
+		result += point(r)[2:]
 	}
 
 	result += b128.Bytes(i.A.Int)[2:]
@@ -27,6 +39,60 @@ func (i *InnerProductProof) Serialize() string {
 	return result
 }
 
+// UnserializeInnerProductProof parses a standalone inner product proof, the
+// number of rounds is recovered from the length.
+func UnserializeInnerProductProof(proof string) (*InnerProductProof, error) {
+	reader, err := newProofReader(proof)
+	if err != nil {
+		return nil, err
+	}
+	return unserializeStandaloneInnerProductProof(reader)
+}
+
+// UnserializeInnerProductProofCompressed is the inverse of SerializeCompressed.
+func UnserializeInnerProductProofCompressed(proof string) (*InnerProductProof, error) {
+	reader, err := newCompressedProofReader(proof)
+	if err != nil {
+		return nil, err
+	}
+	return unserializeStandaloneInnerProductProof(reader)
+}
+
+func unserializeStandaloneInnerProductProof(reader *proofReader) (*InnerProductProof, error) {
+	var round = reader.size(2, 0)
+	if reader.Len() < 64 || (reader.Len()-64)%round != 0 {
+		return nil, fmt.Errorf("invalid inner product proof length %d", reader.Len())
+	}
+	result, err := unserializeInnerProductProof(reader, (reader.Len()-64)/round)
+	if err != nil {
+		return nil, err
+	}
+	return result, reader.finish()
+}
+
+func (i *InnerProductProof) GetL() []Point          { return i.L }
+func (i *InnerProductProof) GetR() []Point          { return i.R }
+func (i *InnerProductProof) GetA() *ebigint.NBigInt { return i.A }
+func (i *InnerProductProof) GetB() *ebigint.NBigInt { return i.B }
+
+func unserializeInnerProductProof(reader *proofReader, logN int) (*InnerProductProof, error) {
+	var err error
+	proof := &InnerProductProof{}
+	if proof.L, err = reader.readPoints(logN); err != nil {
+		return nil, err
+	}
+	if proof.R, err = reader.readPoints(logN); err != nil {
+		return nil, err
+	}
+	if proof.A, err = reader.readScalar(); err != nil {
+		return nil, err
+	}
+	if proof.B, err = reader.readScalar(); err != nil {
+		return nil, err
+	}
+	return proof, nil
+}
+
 func generateProof(base *GeneratorParams, P Point, as *FieldVector, bs *FieldVector,
 	ls []Point, rs []Point, previousChallenge *ebigint.NBigInt) *InnerProductProof {
 	var n = as.Length()
@@ -117,3 +183,83 @@ func (t InnerProductProver) GenerateProof(statement InnerProduct_statement,
 	r := witness.R
 	return generateProof(base, P, l, r, []Point{}, []Point{}, salt)
 }
+
+type InnerProductVerifier struct {
+}
+
+// Verify is a port of InnerProductVerifier.sol, statement.PrimeBase carries
+// the overridden parameters (u, gs, hPrimes).
+func (t InnerProductVerifier) Verify(statement InnerProduct_statement,
+	proof *InnerProductProof, salt *ebigint.NBigInt) error {
+	var logN = len(proof.L)
+	var n = 1 << uint(logN)
+	if len(proof.R) != logN {
+		return errors.New("inner product proof L/R length mismatch")
+	}
+	base := statement.PrimeBase
+	if base.GetGS().Length() < n || base.GetHS().Length() < n {
+		return errors.New("inner product proof is too long for the parameters")
+	}
+
+	var P = statement.P
+	var o = salt
+	var challenges = make([]*ebigint.NBigInt, logN)
+	arguments := abi.Arguments{
+		{
+			Type: bytes32_T,
+		},
+		{
+			Type: bytes32_2T,
+		},
+		{
+			Type: bytes32_2T,
+		},
+	}
+	for i := 0; i < logN; i++ {
+		bytes, err := arguments.Pack(
+			parseBigInt2ABI_Bytes32(o),
+			parsePoint2ABI_Bytes32_2(proof.L[i]),
+			parsePoint2ABI_Bytes32_2(proof.R[i]),
+		)
+		if err != nil {
+			return err
+		}
+		o = Hash(hex.EncodeToString(bytes))
+		challenges[i] = o
+		P = P.Add(proof.L[i].Mul(o.RedExp(big.NewInt(2))).Add(proof.R[i].Mul(o.RedInvm().RedExp(big.NewInt(2)))))
+	}
+
+	var otherExponents = make([]*ebigint.NBigInt, n)
+	otherExponents[0] = ebigint.NewNBigInt(1).ToRed(b128.Q())
+	for i := 0; i < logN; i++ {
+		otherExponents[0] = otherExponents[0].RedMul(challenges[i])
+	}
+	otherExponents[0] = otherExponents[0].RedInvm()
+
+	var bitSet = make([]bool, n)
+	for i := 0; i < n/2; i++ {
+		for j := 0; (1<<uint(j))+i < n; j++ {
+			var i1 = i + (1 << uint(j))
+			if !bitSet[i1] {
+				var temp = challenges[logN-1-j].RedExp(big.NewInt(2))
+				otherExponents[i1] = otherExponents[i].RedMul(temp)
+				bitSet[i1] = true
+			}
+		}
+	}
+
+	var gs = base.GetGS().GetVector()
+	var hs = base.GetHS().GetVector()
+	var gTemp = b128.Zero()
+	var hTemp = b128.Zero()
+	for i := 0; i < n; i++ {
+		gTemp = gTemp.Add(gs[i].Mul(otherExponents[i]))
+		hTemp = hTemp.Add(hs[i].Mul(otherExponents[n-1-i]))
+	}
+
+	var expect = gTemp.Mul(proof.A).Add(hTemp.Mul(proof.B)).Add(base.GetH().Mul(proof.A.RedMul(proof.B)))
+	if !expect.Equal(P) {
+		return errors.New("inner product equality check failure")
+	}
+	return nil
+}
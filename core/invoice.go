@@ -0,0 +1,279 @@
+package core
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/url"
+	"strconv"
+	"time"
+
+	ethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/hpb-project/HCash-SDK/common"
+	"github.com/hpb-project/HCash-SDK/common/types"
+	"github.com/hpb-project/HCash-SDK/core/ebigint"
+)
+
+// An Invoice asks for a payment to y. The recipient signs it with the
+// message signature of its Zether key, so a sender that got it over an
+// untrusted channel knows that y is the key of whoever made the invoice.
+// The signed message is
+//
+//	"HCash invoice v1\n" || y || amount || contract || chain id || expiry || nonce || memo
+//
+// with the integers big-endian, 4 bytes for amount and 8 for chain id and
+// expiry. The URI form fits in a QR code:
+//
+//	hcash:<address of y and contract>?amount=..&chain=..&expiry=..&memo=..&nonce=..&sig=<c || s>
+const (
+	InvoiceScheme = "hcash"
+	// MaxInvoiceMemo is the longest memo in bytes.
+	MaxInvoiceMemo = 256
+
+	invoiceDomain = "HCash invoice v1\n"
+)
+
+var (
+	ErrInvalidInvoice = errors.New("invalid invoice")
+	ErrInvoiceExpired = errors.New("invoice expired")
+)
+
+type Invoice struct {
+	Y types.Point
+	// Amount is the requested value, 0 leaves it to the sender.
+	Amount   uint32
+	Contract ethcommon.Address
+	ChainID  uint64
+	// Expiry is in unix seconds.
+	Expiry int64
+	Memo   string
+	Nonce  [16]byte
+	C, S   *ebigint.NBigInt
+}
+
+func (this Invoice) message() []byte {
+	var b bytes.Buffer
+	var n [8]byte
+	b.WriteString(invoiceDomain)
+	b.Write(this.Y.Bytes())
+	binary.BigEndian.PutUint32(n[:4], this.Amount)
+	b.Write(n[:4])
+	b.Write(this.Contract.Bytes())
+	binary.BigEndian.PutUint64(n[:], this.ChainID)
+	b.Write(n[:])
+	binary.BigEndian.PutUint64(n[:], uint64(this.Expiry))
+	b.Write(n[:])
+	b.Write(this.Nonce[:])
+	b.WriteString(this.Memo)
+	return b.Bytes()
+}
+
+func (this Invoice) check() error {
+	if this.Y.IsIdentity() {
+		return fmt.Errorf("%w: no recipient", ErrInvalidInvoice)
+	}
+	if len(this.Memo) > MaxInvoiceMemo {
+		return fmt.Errorf("%w: memo of %d bytes", ErrInvalidInvoice, len(this.Memo))
+	}
+	if this.Expiry <= 0 {
+		return fmt.Errorf("%w: no expiry", ErrInvalidInvoice)
+	}
+	return nil
+}
+
+// Sign signs the invoice with the key of Y, a zero nonce is replaced by a
+// random one first.
+func (this *Invoice) Sign(key KeyHandle) error {
+	if err := this.check(); err != nil {
+		return err
+	}
+	y, err := key.PublicKey()
+	if err != nil {
+		return err
+	}
+	if y != this.Y {
+		return ErrKeyMismatch
+	}
+	if this.Nonce == [16]byte{} {
+		if _, err := rand.Read(this.Nonce[:]); err != nil {
+			return err
+		}
+	}
+	this.C, this.S, err = key.SignMessage(this.message())
+	return err
+}
+
+// Verify checks the invoice of a payment to contract on chainID at now,
+// a wallet runs it before proving the transfer.
+func (this Invoice) Verify(contract ethcommon.Address, chainID uint64, now time.Time) error {
+	if err := this.check(); err != nil {
+		return err
+	}
+	if this.Contract != contract {
+		return fmt.Errorf("%w: for contract %s", ErrInvalidInvoice, this.Contract.Hex())
+	}
+	if this.ChainID != chainID {
+		return fmt.Errorf("%w: for chain %d", ErrInvalidInvoice, this.ChainID)
+	}
+	if now.Unix() > this.Expiry {
+		return ErrInvoiceExpired
+	}
+	if this.C == nil || this.S == nil {
+		return ErrInvalidSignature
+	}
+	return VerifyMessage(this.Y, this.message(), this.C, this.S)
+}
+
+// signature is c || s, 64 bytes.
+func (this Invoice) signature() []byte {
+	var sig = make([]byte, 64)
+	if this.C != nil && this.S != nil {
+		this.C.FillBytes(sig[:32])
+		this.S.FillBytes(sig[32:])
+	}
+	return sig
+}
+
+func (this *Invoice) setSignature(sig []byte) error {
+	if len(sig) != 64 {
+		return fmt.Errorf("%w: signature of %d bytes", ErrInvalidInvoice, len(sig))
+	}
+	this.C = ebigint.ToNBigInt(new(big.Int).SetBytes(sig[:32]))
+	this.S = ebigint.ToNBigInt(new(big.Int).SetBytes(sig[32:]))
+	return nil
+}
+
+// URI encodes the signed invoice, prefix is the address prefix.
+func (this Invoice) URI(prefix string) (string, error) {
+	if err := this.check(); err != nil {
+		return "", err
+	}
+	address, err := types.EncodeAddress(types.Address{Prefix: prefix, Y: this.Y, Contract: this.Contract.Bytes()})
+	if err != nil {
+		return "", err
+	}
+	var query = url.Values{}
+	query.Set("amount", strconv.FormatUint(uint64(this.Amount), 10))
+	query.Set("chain", strconv.FormatUint(this.ChainID, 10))
+	query.Set("expiry", strconv.FormatInt(this.Expiry, 10))
+	if this.Memo != "" {
+		query.Set("memo", this.Memo)
+	}
+	query.Set("nonce", hex.EncodeToString(this.Nonce[:]))
+	query.Set("sig", hex.EncodeToString(this.signature()))
+	return InvoiceScheme + ":" + address + "?" + query.Encode(), nil
+}
+
+// ParseInvoiceURI decodes the URI of an invoice, it does not Verify it.
+func ParseInvoiceURI(uri string) (Invoice, error) {
+	u, err := url.Parse(uri)
+	if err != nil || u.Scheme != InvoiceScheme || u.Opaque == "" {
+		return Invoice{}, fmt.Errorf("%w: not a %s URI", ErrInvalidInvoice, InvoiceScheme)
+	}
+	address, err := types.DecodeAddress(u.Opaque)
+	if err != nil {
+		return Invoice{}, err
+	}
+	if address.Contract == nil {
+		return Invoice{}, fmt.Errorf("%w: address without contract", ErrInvalidInvoice)
+	}
+	var invoice = Invoice{Y: address.Y, Contract: ethcommon.BytesToAddress(address.Contract)}
+	query, err := url.ParseQuery(u.RawQuery)
+	if err != nil {
+		return Invoice{}, fmt.Errorf("%w: %s", ErrInvalidInvoice, err.Error())
+	}
+	amount, err := strconv.ParseUint(query.Get("amount"), 10, 32)
+	if err != nil {
+		return Invoice{}, fmt.Errorf("%w: amount", ErrInvalidInvoice)
+	}
+	invoice.Amount = uint32(amount)
+	if invoice.ChainID, err = strconv.ParseUint(query.Get("chain"), 10, 64); err != nil {
+		return Invoice{}, fmt.Errorf("%w: chain", ErrInvalidInvoice)
+	}
+	if invoice.Expiry, err = strconv.ParseInt(query.Get("expiry"), 10, 64); err != nil {
+		return Invoice{}, fmt.Errorf("%w: expiry", ErrInvalidInvoice)
+	}
+	invoice.Memo = query.Get("memo")
+	nonce, err := hex.DecodeString(query.Get("nonce"))
+	if err != nil || len(nonce) != len(invoice.Nonce) {
+		return Invoice{}, fmt.Errorf("%w: nonce", ErrInvalidInvoice)
+	}
+	copy(invoice.Nonce[:], nonce)
+	sig, err := hex.DecodeString(query.Get("sig"))
+	if err != nil {
+		return Invoice{}, fmt.Errorf("%w: signature", ErrInvalidInvoice)
+	}
+	if err := invoice.setSignature(sig); err != nil {
+		return Invoice{}, err
+	}
+	if err := invoice.check(); err != nil {
+		return Invoice{}, err
+	}
+	return invoice, nil
+}
+
+type jsonInvoice struct {
+	Y        types.Point `json:"y"`
+	Amount   uint32      `json:"amount"`
+	Contract string      `json:"contract"`
+	ChainID  uint64      `json:"chainId"`
+	Expiry   int64       `json:"expiry"`
+	Memo     string      `json:"memo,omitempty"`
+	Nonce    string      `json:"nonce"`
+	C        string      `json:"c,omitempty"`
+	S        string      `json:"s,omitempty"`
+}
+
+func (this Invoice) MarshalJSON() ([]byte, error) {
+	var j = jsonInvoice{
+		Y:        this.Y,
+		Amount:   this.Amount,
+		Contract: this.Contract.Hex(),
+		ChainID:  this.ChainID,
+		Expiry:   this.Expiry,
+		Memo:     this.Memo,
+		Nonce:    "0x" + hex.EncodeToString(this.Nonce[:]),
+	}
+	if this.C != nil && this.S != nil {
+		j.C, j.S = b128.Bytes(this.C.Int), b128.Bytes(this.S.Int)
+	}
+	return json.Marshal(j)
+}
+
+func (this *Invoice) UnmarshalJSON(input []byte) error {
+	var j jsonInvoice
+	if err := json.Unmarshal(input, &j); err != nil {
+		return err
+	}
+	if !ethcommon.IsHexAddress(j.Contract) {
+		return fmt.Errorf("%w: contract", ErrInvalidInvoice)
+	}
+	var invoice = Invoice{
+		Y:        j.Y,
+		Amount:   j.Amount,
+		Contract: ethcommon.HexToAddress(j.Contract),
+		ChainID:  j.ChainID,
+		Expiry:   j.Expiry,
+		Memo:     j.Memo,
+	}
+	nonce, err := hex.DecodeString(common.HexWithout0x(j.Nonce))
+	if err != nil || len(nonce) != len(invoice.Nonce) {
+		return fmt.Errorf("%w: nonce", ErrInvalidInvoice)
+	}
+	copy(invoice.Nonce[:], nonce)
+	if j.C != "" || j.S != "" {
+		c, okc := new(big.Int).SetString(common.HexWithout0x(j.C), 16)
+		s, oks := new(big.Int).SetString(common.HexWithout0x(j.S), 16)
+		if !okc || !oks || c.Cmp(b128.Q().Int) >= 0 || s.Cmp(b128.Q().Int) >= 0 {
+			return fmt.Errorf("%w: signature", ErrInvalidInvoice)
+		}
+		invoice.C, invoice.S = ebigint.ToNBigInt(c), ebigint.ToNBigInt(s)
+	}
+	*this = invoice
+	return nil
+}
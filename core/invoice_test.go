@@ -0,0 +1,74 @@
+package core
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	ethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/hpb-project/HCash-SDK/common/types"
+	"gotest.tools/assert"
+)
+
+func TestInvoice(t *testing.T) {
+	key := NewMemoryKey(testScalar)
+	y, _ := key.PublicKey()
+	zsc := ethcommon.HexToAddress("0xE4920905e06c6B6070477c40B85756ffDa3cD3E6")
+	var invoice = Invoice{Y: y, Amount: 25, Contract: zsc, ChainID: 269, Expiry: 1700000000, Memo: "order #42 & co"}
+	assert.NilError(t, invoice.Sign(key))
+	assert.Assert(t, invoice.Nonce != [16]byte{})
+	now := time.Unix(invoice.Expiry-60, 0)
+	assert.NilError(t, invoice.Verify(zsc, 269, now))
+
+	uri, err := invoice.URI(types.MainnetPrefix)
+	assert.NilError(t, err)
+	assert.Assert(t, strings.HasPrefix(uri, "hcash:hc1"), uri)
+	fromURI, err := ParseInvoiceURI(uri)
+	assert.NilError(t, err)
+	assert.NilError(t, fromURI.Verify(zsc, 269, now))
+	assert.Equal(t, fromURI.Memo, invoice.Memo)
+
+	data, err := json.Marshal(invoice)
+	assert.NilError(t, err)
+	var fromJSON Invoice
+	assert.NilError(t, json.Unmarshal(data, &fromJSON))
+	assert.NilError(t, fromJSON.Verify(zsc, 269, now))
+	again, _ := json.Marshal(fromJSON)
+	assert.Equal(t, string(again), string(data))
+
+	assert.Assert(t, errors.Is(invoice.Verify(zsc, 269, time.Unix(invoice.Expiry+1, 0)), ErrInvoiceExpired))
+	assert.Assert(t, errors.Is(invoice.Verify(zsc, 1, now), ErrInvalidInvoice))
+	assert.Assert(t, errors.Is(invoice.Verify(ethcommon.Address{}, 269, now), ErrInvalidInvoice))
+
+	// every signed field is covered.
+	for _, change := range []func(*Invoice){
+		func(i *Invoice) { i.Amount++ },
+		func(i *Invoice) { i.Expiry++ },
+		func(i *Invoice) { i.Memo = "order #43" },
+		func(i *Invoice) { i.Nonce[0] ^= 1 },
+		func(i *Invoice) { i.Y = b128.Serialize(FixedG().Mul(testScalar.RedAdd(testScalar))) },
+	} {
+		changed := invoice
+		change(&changed)
+		assert.Assert(t, errors.Is(changed.Verify(zsc, 269, now), ErrInvalidSignature))
+	}
+
+	// only the key of y signs.
+	other := invoice
+	assert.Assert(t, errors.Is(other.Sign(NewMemoryKey(testScalar.RedAdd(testScalar))), ErrKeyMismatch))
+
+	for _, bad := range []string{
+		strings.Replace(uri, "amount=25", "amount=26", 1),
+		strings.Replace(uri, "hcash:", "zether:", 1),
+		strings.Replace(uri, "&sig=", "&sig=00", 1),
+		uri[:strings.Index(uri, "?")],
+	} {
+		i, err := ParseInvoiceURI(bad)
+		if err == nil {
+			err = i.Verify(zsc, 269, now)
+		}
+		assert.Assert(t, err != nil, bad)
+	}
+}
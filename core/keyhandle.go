@@ -0,0 +1,194 @@
+package core
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+
+	"github.com/hpb-project/HCash-SDK/common/types"
+	"github.com/hpb-project/HCash-SDK/core/ebigint"
+)
+
+var ErrUnknownSession = errors.New("unknown, used or dropped key session")
+
+// maxKeySessions bounds the sessions committed but not answered yet, a
+// prover that fails in between leaves its session behind. Commit drops the
+// oldest session beyond it.
+const maxKeySessions = 64
+
+// KeyHandle is every operation that needs the account secret x, so that x
+// can live in another process. Decrypt answers for any CR, a handle must
+// only be reachable by the owner of the account.
+type KeyHandle interface {
+	// PublicKey returns y = g^x.
+	PublicKey() (types.Point, error)
+	// U returns the nonce gEpoch^x of epoch.
+	U(epoch int) (types.Point, error)
+	// Decrypt returns g^b = CL - x*CR.
+	Decrypt(CL, CR types.Point) (types.Point, error)
+	// Commit draws a fresh k and returns base^k for every base. The
+	// session answers exactly one Respond.
+	Commit(bases []types.Point) (*KeyCommitment, error)
+	// Respond returns k + c*x for the k of session, the sk response of
+	// the transfer and burn proofs.
+	Respond(session uint64, c *ebigint.NBigInt) (*ebigint.NBigInt, error)
+	// Sign makes the register signature of the ZSC address.
+	Sign(address []byte) (*ebigint.NBigInt, *ebigint.NBigInt, error)
+	// SignMessage is core.SignMessage with the key.
+	SignMessage(message []byte) (*ebigint.NBigInt, *ebigint.NBigInt, error)
+}
+
+// KeyCommitment is the answer of KeyHandle.Commit.
+type KeyCommitment struct {
+	Session uint64
+	Points  []types.Point
+}
+
+// MemoryKey is a KeyHandle holding x in memory.
+type MemoryKey struct {
+	account Account
+	random  io.Reader
+
+	lock     sync.Mutex
+	next     uint64
+	sessions map[uint64]*ebigint.NBigInt
+}
+
+// NewMemoryKey returns the handle of x, the nonces of Commit are read from
+// crypto/rand.
+func NewMemoryKey(x *ebigint.NBigInt) *MemoryKey {
+	x = ebigint.ToNBigInt(x.Int).ForceRed(b128.Q())
+	return &MemoryKey{
+		account:  Account{X: x, Y: b128.Serialize(FixedG().Mul(x))},
+		sessions: make(map[uint64]*ebigint.NBigInt),
+	}
+}
+
+// SetRandom replaces the source of the Commit nonces, nil is crypto/rand.
+func (this *MemoryKey) SetRandom(r io.Reader) {
+	this.random = r
+}
+
+func (this *MemoryKey) PublicKey() (types.Point, error) {
+	return this.account.Y, nil
+}
+
+func (this *MemoryKey) U(epoch int) (types.Point, error) {
+	return b128.Serialize(U(epoch, this.account.X)), nil
+}
+
+func (this *MemoryKey) Decrypt(CL, CR types.Point) (types.Point, error) {
+	nCL, err := b128.DecodePoint(CL)
+	if err != nil {
+		return types.Point{}, fmt.Errorf("CL: %w", err)
+	}
+	nCR, err := b128.DecodePoint(CR)
+	if err != nil {
+		return types.Point{}, fmt.Errorf("CR: %w", err)
+	}
+	return b128.Serialize(nCL.Add(nCR.Mul(this.account.X.RedNeg()))), nil
+}
+
+func (this *MemoryKey) Commit(bases []types.Point) (*KeyCommitment, error) {
+	var points = make([]Point, len(bases))
+	for i, base := range bases {
+		p, err := b128.DecodePoint(base)
+		if err != nil {
+			return nil, fmt.Errorf("base %d: %w", i, err)
+		}
+		points[i] = p
+	}
+	random := newScalarReader(this.random)
+	k := random.next()
+	if random.err != nil {
+		return nil, random.err
+	}
+
+	var commitment = &KeyCommitment{Points: make([]types.Point, len(points))}
+	for i, p := range points {
+		commitment.Points[i] = b128.Serialize(p.Mul(k))
+	}
+	this.lock.Lock()
+	defer this.lock.Unlock()
+	this.next++
+	delete(this.sessions, this.next-maxKeySessions)
+	commitment.Session = this.next
+	this.sessions[commitment.Session] = k
+	return commitment, nil
+}
+
+func (this *MemoryKey) Respond(session uint64, c *ebigint.NBigInt) (*ebigint.NBigInt, error) {
+	if c == nil || c.Sign() < 0 || c.Cmp(b128.Q().Int) >= 0 {
+		return nil, fmt.Errorf("%w: challenge", ErrInvalidScalar)
+	}
+	this.lock.Lock()
+	k, ok := this.sessions[session]
+	delete(this.sessions, session)
+	this.lock.Unlock()
+	if !ok {
+		return nil, ErrUnknownSession
+	}
+	return k.RedAdd(c.ToRed(b128.Q()).RedMul(this.account.X)), nil
+}
+
+func (this *MemoryKey) Sign(address []byte) (*ebigint.NBigInt, *ebigint.NBigInt, error) {
+	return Sign(address, this.account)
+}
+
+func (this *MemoryKey) SignMessage(message []byte) (*ebigint.NBigInt, *ebigint.NBigInt, error) {
+	return SignMessage(this.account, message)
+}
+
+// ReadBalanceWithKey is ReadBalance with the decryption done by key.
+func ReadBalanceWithKey(CL, CR types.Point, key KeyHandle) (uint32, error) {
+	return DefaultBalanceTable().ReadBalanceWithKey(CL, CR, key)
+}
+
+// ReadBalanceWithKey is ReadBalance with the decryption done by key.
+func (t *BalanceTable) ReadBalanceWithKey(CL, CR types.Point, key KeyHandle) (uint32, error) {
+	gB, err := key.Decrypt(CL, CR)
+	if err != nil {
+		return 0, err
+	}
+	p, err := b128.DecodePoint(gB)
+	if err != nil {
+		return 0, err
+	}
+	return t.Solve(p)
+}
+
+// witnessKey is the key of a witness, key or else a MemoryKey of sk drawing
+// its nonce from the random of the prover.
+func witnessKey(key KeyHandle, sk string, random io.Reader) (KeyHandle, error) {
+	if key != nil {
+		return key, nil
+	}
+	x, err := decodeScalar("sk", sk)
+	if err != nil {
+		return nil, err
+	}
+	memory := NewMemoryKey(x)
+	memory.SetRandom(random)
+	return memory, nil
+}
+
+// keyCommit is Commit on core points.
+func keyCommit(key KeyHandle, bases ...Point) (uint64, []Point, error) {
+	var serialized = make([]types.Point, len(bases))
+	for i, base := range bases {
+		serialized[i] = b128.Serialize(base)
+	}
+	commitment, err := key.Commit(serialized)
+	if err != nil {
+		return 0, nil, err
+	}
+	if len(commitment.Points) != len(bases) {
+		return 0, nil, fmt.Errorf("key commitment has %d points, want %d", len(commitment.Points), len(bases))
+	}
+	points, err := decodePoints("key commitment", commitment.Points)
+	if err != nil {
+		return 0, nil, err
+	}
+	return commitment.Session, points.GetVector(), nil
+}
@@ -0,0 +1,107 @@
+package core
+
+import (
+	"errors"
+	"net"
+	"path/filepath"
+	"testing"
+
+	ethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/hpb-project/HCash-SDK/common"
+	"github.com/hpb-project/HCash-SDK/core/ebigint"
+	"gotest.tools/assert"
+)
+
+func TestMemoryKey(t *testing.T) {
+	istatement, iwitness := transferVector()
+	x := ebigint.FromHex(iwitness.SK)
+
+	// the sk of the witness is a MemoryKey drawing from the prover random,
+	// so the proof does not change with the key.
+	proof, err := ProveTransferWithReader(istatement, iwitness, 1, testRandom())
+	assert.NilError(t, err)
+	key := NewMemoryKey(x)
+	key.SetRandom(testRandom())
+	withKey := iwitness
+	withKey.SK = ""
+	withKey.Key = key
+	keyProof, err := ProveTransferWithReader(istatement, withKey, 1, testRandom())
+	assert.NilError(t, err)
+	assert.Equal(t, keyProof, proof)
+
+	u, err := key.U(istatement.Epoch)
+	assert.NilError(t, err)
+	assert.NilError(t, VerifyTransfer(istatement, u, proof))
+
+	// a session answers once.
+	commitment, err := key.Commit(istatement.Y)
+	assert.NilError(t, err)
+	_, err = key.Respond(commitment.Session, testScalar)
+	assert.NilError(t, err)
+	_, err = key.Respond(commitment.Session, testScalar)
+	assert.Assert(t, errors.Is(err, ErrUnknownSession))
+
+	// sessions never answered are dropped past maxKeySessions.
+	first, err := key.Commit(istatement.Y[:1])
+	assert.NilError(t, err)
+	for i := 0; i < maxKeySessions; i++ {
+		commitment, err = key.Commit(istatement.Y[:1])
+		assert.NilError(t, err)
+	}
+	assert.Equal(t, len(key.sessions), maxKeySessions)
+	_, err = key.Respond(first.Session, testScalar)
+	assert.Assert(t, errors.Is(err, ErrUnknownSession))
+	_, err = key.Respond(commitment.Session, testScalar)
+	assert.NilError(t, err)
+
+	// the key of another account.
+	withKey.Key = NewMemoryKey(testScalar)
+	assert.Assert(t, errors.Is(CheckTransfer(istatement, withKey), ErrKeyMismatch))
+}
+
+func TestSocketKey(t *testing.T) {
+	statement, witness := burnVector()
+	x := ebigint.FromHex(witness.SK)
+
+	path := filepath.Join(t.TempDir(), "key.sock")
+	l, err := net.Listen("unix", path)
+	assert.NilError(t, err)
+	defer l.Close()
+	go ServeKey(l, NewMemoryKey(x))
+
+	key, err := DialKey(path)
+	assert.NilError(t, err)
+	defer key.Close()
+
+	y, err := key.PublicKey()
+	assert.NilError(t, err)
+	assert.Equal(t, y, statement.Y)
+
+	witness.SK = ""
+	witness.Key = key
+	proof, err := ProveBurn(statement, witness)
+	assert.NilError(t, err)
+	u, err := key.U(statement.Epoch)
+	assert.NilError(t, err)
+	assert.Equal(t, u, b128.Serialize(U(statement.Epoch, x)))
+	assert.NilError(t, VerifyBurn(statement, u, proof))
+
+	// CLn - x*CRn = g^bDiff.
+	balance, err := ReadBalanceWithKey(statement.CLn, statement.CRn, key)
+	assert.NilError(t, err)
+	assert.Equal(t, balance, uint32(witness.BDiff))
+
+	address := common.FromHex("0xE4920905e06c6B6070477c40B85756ffDa3cD3E6")
+	c, s, err := key.Sign(address)
+	assert.NilError(t, err)
+	assert.NilError(t, VerifySign(address, y, c, s))
+
+	message := ethcommon.FromHex("0x68636173680a")
+	c, s, err = key.SignMessage(message)
+	assert.NilError(t, err)
+	assert.NilError(t, VerifyMessage(y, message, c, s))
+
+	// errors of the key process come back over the socket.
+	_, err = key.Respond(1<<40, testScalar)
+	assert.ErrorContains(t, err, ErrUnknownSession.Error())
+}
@@ -0,0 +1,181 @@
+package core
+
+import (
+	"math/big"
+	"net"
+	"net/rpc"
+
+	"github.com/hpb-project/HCash-SDK/common/types"
+	"github.com/hpb-project/HCash-SDK/core/ebigint"
+)
+
+// The key socket serves a KeyHandle with net/rpc, so that x stays in a
+// separate process:
+//
+//	l, _ := net.Listen("unix", "/run/user/1000/hcash-key.sock")
+//	go core.ServeKey(l, core.NewMemoryKey(x))
+//
+//	key, _ := core.DialKey("/run/user/1000/hcash-key.sock")
+//	witness.Key = key
+//
+// Anyone who can connect can decrypt and prove with the key, the socket
+// must be protected by its file permissions.
+const keyServiceName = "HCashKey"
+
+// KeyChallenge is the wire form of the Respond arguments.
+type KeyChallenge struct {
+	Session uint64
+	C       *big.Int
+}
+
+// KeySignature is the wire form of a Schnorr signature (c, s).
+type KeySignature struct {
+	C *big.Int
+	S *big.Int
+}
+
+// keyService is the rpc receiver, its methods follow the net/rpc rules.
+type keyService struct {
+	key KeyHandle
+}
+
+func (this *keyService) PublicKey(_ int, reply *types.Point) (err error) {
+	*reply, err = this.key.PublicKey()
+	return err
+}
+
+func (this *keyService) U(epoch int, reply *types.Point) (err error) {
+	*reply, err = this.key.U(epoch)
+	return err
+}
+
+func (this *keyService) Decrypt(args [2]types.Point, reply *types.Point) (err error) {
+	*reply, err = this.key.Decrypt(args[0], args[1])
+	return err
+}
+
+func (this *keyService) Commit(bases []types.Point, reply *KeyCommitment) error {
+	commitment, err := this.key.Commit(bases)
+	if err != nil {
+		return err
+	}
+	*reply = *commitment
+	return nil
+}
+
+func (this *keyService) Respond(args KeyChallenge, reply *big.Int) error {
+	if args.C == nil {
+		return ErrInvalidScalar
+	}
+	s, err := this.key.Respond(args.Session, ebigint.ToNBigInt(args.C))
+	if err != nil {
+		return err
+	}
+	reply.Set(s.Int)
+	return nil
+}
+
+func (this *keyService) Sign(address []byte, reply *KeySignature) error {
+	c, s, err := this.key.Sign(address)
+	if err != nil {
+		return err
+	}
+	*reply = KeySignature{C: c.Int, S: s.Int}
+	return nil
+}
+
+func (this *keyService) SignMessage(message []byte, reply *KeySignature) error {
+	c, s, err := this.key.SignMessage(message)
+	if err != nil {
+		return err
+	}
+	*reply = KeySignature{C: c.Int, S: s.Int}
+	return nil
+}
+
+// ServeKey answers the connections of l with key until l is closed.
+func ServeKey(l net.Listener, key KeyHandle) error {
+	server := rpc.NewServer()
+	if err := server.RegisterName(keyServiceName, &keyService{key: key}); err != nil {
+		return err
+	}
+	server.Accept(l)
+	return nil
+}
+
+// SocketKey is a KeyHandle served by ServeKey in another process. Errors of
+// the remote key come back as plain errors, errors.Is does not see through
+// the socket.
+type SocketKey struct {
+	client *rpc.Client
+}
+
+// DialKey connects to the key served on the unix socket at path.
+func DialKey(path string) (*SocketKey, error) {
+	client, err := rpc.Dial("unix", path)
+	if err != nil {
+		return nil, err
+	}
+	return &SocketKey{client: client}, nil
+}
+
+func (this *SocketKey) Close() error {
+	return this.client.Close()
+}
+
+func (this *SocketKey) call(method string, args interface{}, reply interface{}) error {
+	return this.client.Call(keyServiceName+"."+method, args, reply)
+}
+
+func (this *SocketKey) PublicKey() (types.Point, error) {
+	var y types.Point
+	err := this.call("PublicKey", 0, &y)
+	return y, err
+}
+
+func (this *SocketKey) U(epoch int) (types.Point, error) {
+	var u types.Point
+	err := this.call("U", epoch, &u)
+	return u, err
+}
+
+func (this *SocketKey) Decrypt(CL, CR types.Point) (types.Point, error) {
+	var gB types.Point
+	err := this.call("Decrypt", [2]types.Point{CL, CR}, &gB)
+	return gB, err
+}
+
+func (this *SocketKey) Commit(bases []types.Point) (*KeyCommitment, error) {
+	var commitment KeyCommitment
+	if err := this.call("Commit", bases, &commitment); err != nil {
+		return nil, err
+	}
+	return &commitment, nil
+}
+
+func (this *SocketKey) Respond(session uint64, c *ebigint.NBigInt) (*ebigint.NBigInt, error) {
+	var s = new(big.Int)
+	if err := this.call("Respond", KeyChallenge{Session: session, C: c.Int}, s); err != nil {
+		return nil, err
+	}
+	return ebigint.ToNBigInt(s).ForceRed(b128.Q()), nil
+}
+
+func (this *SocketKey) Sign(address []byte) (*ebigint.NBigInt, *ebigint.NBigInt, error) {
+	return this.sign("Sign", address)
+}
+
+func (this *SocketKey) SignMessage(message []byte) (*ebigint.NBigInt, *ebigint.NBigInt, error) {
+	return this.sign("SignMessage", message)
+}
+
+func (this *SocketKey) sign(method string, data []byte) (*ebigint.NBigInt, *ebigint.NBigInt, error) {
+	var sig KeySignature
+	if err := this.call(method, data, &sig); err != nil {
+		return nil, nil, err
+	}
+	if sig.C == nil || sig.S == nil {
+		return nil, nil, ErrInvalidSignature
+	}
+	return ebigint.ToNBigInt(sig.C), ebigint.ToNBigInt(sig.S), nil
+}
@@ -0,0 +1,264 @@
+package core
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+
+	"github.com/hpb-project/HCash-SDK/common/types"
+	"github.com/hpb-project/HCash-SDK/core/ebigint"
+	"golang.org/x/crypto/argon2"
+	"golang.org/x/crypto/scrypt"
+)
+
+// A keystore holds x encrypted with AES-256-GCM under a key derived from a
+// password, y stays in clear so that a wallet can list its accounts without
+// the password. y is the additional data of the cipher, a keystore with a
+// changed y does not decrypt.
+const (
+	KeystoreVersion = 1
+
+	KDFScrypt   = "scrypt"
+	KDFArgon2id = "argon2id"
+
+	keystoreCipher = "aes-256-gcm"
+	keystoreKeyLen = 32
+
+	// the scrypt parameters of go-ethereum keystores.
+	StandardScryptN = 1 << 18
+	StandardScryptP = 1
+	LightScryptN    = 1 << 12
+	LightScryptP    = 6
+	scryptR         = 8
+
+	// memory in KiB.
+	StandardArgon2Time   = 3
+	StandardArgon2Memory = 64 * 1024
+	LightArgon2Time      = 1
+	LightArgon2Memory    = 4 * 1024
+	argon2Threads        = 4
+
+	// a keystore file can not ask for more than 1 GiB, nor for more than 16
+	// passes over it.
+	maxScryptN      = 1 << 20
+	maxScryptP      = 16
+	maxArgon2Memory = 1 << 20
+	maxArgon2Time   = 16
+)
+
+var (
+	ErrDecrypt         = errors.New("could not decrypt key with given password")
+	ErrInvalidKeystore = errors.New("invalid keystore")
+)
+
+type Keystore struct {
+	Version int            `json:"version"`
+	Y       types.Point    `json:"y"`
+	Crypto  KeystoreCrypto `json:"crypto"`
+}
+
+type KeystoreCrypto struct {
+	Cipher     string    `json:"cipher"`
+	CipherText string    `json:"ciphertext"`
+	Nonce      string    `json:"nonce"`
+	KDF        string    `json:"kdf"`
+	KDFParams  KDFParams `json:"kdfparams"`
+}
+
+// KDFParams are the parameters of KDF, N, R and P for scrypt, Time, Memory
+// and Threads for argon2id.
+type KDFParams struct {
+	Salt    string `json:"salt"`
+	DKLen   int    `json:"dklen"`
+	N       int    `json:"n,omitempty"`
+	R       int    `json:"r,omitempty"`
+	P       int    `json:"p,omitempty"`
+	Time    uint32 `json:"time,omitempty"`
+	Memory  uint32 `json:"memory,omitempty"`
+	Threads uint8  `json:"threads,omitempty"`
+}
+
+// KeystoreOptions selects the KDF, an empty KDF is scrypt. Light
+// parameters are for mobile devices and tests.
+type KeystoreOptions struct {
+	KDF   string `json:"kdf"`
+	Light bool   `json:"light"`
+}
+
+func (o KeystoreOptions) params() (string, KDFParams, error) {
+	var params = KDFParams{DKLen: keystoreKeyLen}
+	switch o.KDF {
+	case "", KDFScrypt:
+		params.N, params.R, params.P = StandardScryptN, scryptR, StandardScryptP
+		if o.Light {
+			params.N, params.P = LightScryptN, LightScryptP
+		}
+		return KDFScrypt, params, nil
+	case KDFArgon2id:
+		params.Time, params.Memory, params.Threads = StandardArgon2Time, StandardArgon2Memory, argon2Threads
+		if o.Light {
+			params.Time, params.Memory = LightArgon2Time, LightArgon2Memory
+		}
+		return KDFArgon2id, params, nil
+	}
+	return "", params, fmt.Errorf("%w: unknown kdf %q", ErrInvalidKeystore, o.KDF)
+}
+
+func deriveKey(kdf string, params KDFParams, password string) ([]byte, error) {
+	salt, err := hex.DecodeString(params.Salt)
+	if err != nil || len(salt) == 0 {
+		return nil, fmt.Errorf("%w: salt", ErrInvalidKeystore)
+	}
+	if params.DKLen != keystoreKeyLen {
+		return nil, fmt.Errorf("%w: dklen %d", ErrInvalidKeystore, params.DKLen)
+	}
+	switch kdf {
+	case KDFScrypt:
+		if params.N < 2 || params.N > maxScryptN || params.N&(params.N-1) != 0 ||
+			params.R != scryptR || params.P < 1 || params.P > maxScryptP {
+			return nil, fmt.Errorf("%w: scrypt parameters", ErrInvalidKeystore)
+		}
+		return scrypt.Key([]byte(password), salt, params.N, params.R, params.P, params.DKLen)
+	case KDFArgon2id:
+		if params.Time == 0 || params.Time > maxArgon2Time || params.Memory == 0 || params.Memory > maxArgon2Memory || params.Threads == 0 {
+			return nil, fmt.Errorf("%w: argon2id parameters", ErrInvalidKeystore)
+		}
+		return argon2.IDKey([]byte(password), salt, params.Time, params.Memory, params.Threads, uint32(params.DKLen)), nil
+	}
+	return nil, fmt.Errorf("%w: unknown kdf %q", ErrInvalidKeystore, kdf)
+}
+
+func keystoreAEAD(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// EncryptKey encrypts the account with password.
+func EncryptKey(account Account, password string, opts KeystoreOptions) (*Keystore, error) {
+	return encryptKey(account, password, opts, rand.Reader)
+}
+
+func encryptKey(account Account, password string, opts KeystoreOptions, random io.Reader) (*Keystore, error) {
+	if _, err := signKey(account); err != nil {
+		return nil, err
+	}
+	kdf, params, err := opts.params()
+	if err != nil {
+		return nil, err
+	}
+	var salt = make([]byte, 32)
+	if _, err := io.ReadFull(random, salt); err != nil {
+		return nil, err
+	}
+	params.Salt = hex.EncodeToString(salt)
+	key, err := deriveKey(kdf, params, password)
+	if err != nil {
+		return nil, err
+	}
+	aead, err := keystoreAEAD(key)
+	if err != nil {
+		return nil, err
+	}
+	var nonce = make([]byte, aead.NonceSize())
+	if _, err := io.ReadFull(random, nonce); err != nil {
+		return nil, err
+	}
+	x := keyBytes(account.X)
+	return &Keystore{
+		Version: KeystoreVersion,
+		Y:       account.Y,
+		Crypto: KeystoreCrypto{
+			Cipher:     keystoreCipher,
+			CipherText: hex.EncodeToString(aead.Seal(nil, nonce, x, account.Y.Bytes())),
+			Nonce:      hex.EncodeToString(nonce),
+			KDF:        kdf,
+			KDFParams:  params,
+		},
+	}, nil
+}
+
+// keyBytes is x as 32 bytes big endian.
+func keyBytes(x *ebigint.NBigInt) []byte {
+	var b = make([]byte, 32)
+	x.FillBytes(b)
+	return b
+}
+
+// Decrypt returns the account, ErrDecrypt means a wrong password or a
+// tampered keystore.
+func (k *Keystore) Decrypt(password string) (Account, error) {
+	if k.Version != KeystoreVersion {
+		return Account{}, fmt.Errorf("%w: version %d", ErrInvalidKeystore, k.Version)
+	}
+	if k.Crypto.Cipher != keystoreCipher {
+		return Account{}, fmt.Errorf("%w: cipher %q", ErrInvalidKeystore, k.Crypto.Cipher)
+	}
+	ciphertext, err := hex.DecodeString(k.Crypto.CipherText)
+	if err != nil {
+		return Account{}, fmt.Errorf("%w: ciphertext", ErrInvalidKeystore)
+	}
+	nonce, err := hex.DecodeString(k.Crypto.Nonce)
+	if err != nil {
+		return Account{}, fmt.Errorf("%w: nonce", ErrInvalidKeystore)
+	}
+	key, err := deriveKey(k.Crypto.KDF, k.Crypto.KDFParams, password)
+	if err != nil {
+		return Account{}, err
+	}
+	aead, err := keystoreAEAD(key)
+	if err != nil {
+		return Account{}, err
+	}
+	if len(nonce) != aead.NonceSize() {
+		return Account{}, fmt.Errorf("%w: nonce", ErrInvalidKeystore)
+	}
+	x, err := aead.Open(nil, nonce, ciphertext, k.Y.Bytes())
+	if err != nil {
+		return Account{}, ErrDecrypt
+	}
+	var account = Account{X: ebigint.FromBytes(x).ForceRed(b128.Q()), Y: k.Y}
+	if _, err := signKey(account); err != nil {
+		return Account{}, err
+	}
+	return account, nil
+}
+
+// ChangePassword returns the keystore of the same account under password,
+// with a new salt and nonce and the KDF of opts.
+func (k *Keystore) ChangePassword(old, password string, opts KeystoreOptions) (*Keystore, error) {
+	account, err := k.Decrypt(old)
+	if err != nil {
+		return nil, err
+	}
+	return EncryptKey(account, password, opts)
+}
+
+// SaveFile writes the keystore, readable by the owner only.
+func (k *Keystore) SaveFile(path string) error {
+	data, err := json.Marshal(k)
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(path, data, 0600)
+}
+
+func LoadKeystoreFile(path string) (*Keystore, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var k Keystore
+	if err := json.Unmarshal(data, &k); err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidKeystore, err.Error())
+	}
+	return &k, nil
+}
@@ -0,0 +1,78 @@
+package core
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"gotest.tools/assert"
+)
+
+func TestKeystore(t *testing.T) {
+	account := CreateAccountWithX(testScalar)
+	for _, kdf := range []string{KDFScrypt, KDFArgon2id} {
+		opts := KeystoreOptions{KDF: kdf, Light: true}
+		ks, err := encryptKey(account, "password", opts, NewDRBG([]byte(kdf)))
+		assert.NilError(t, err)
+		assert.Equal(t, ks.Crypto.KDF, kdf)
+		assert.Equal(t, ks.Y, account.Y)
+
+		decrypted, err := ks.Decrypt("password")
+		assert.NilError(t, err)
+		assert.Assert(t, decrypted.X.Eq(account.X))
+
+		_, err = ks.Decrypt("wrong")
+		assert.Assert(t, errors.Is(err, ErrDecrypt))
+
+		// y is bound to the ciphertext.
+		tampered := *ks
+		tampered.Y = CreateAccountWithX(account.X.RedAdd(account.X)).Y
+		_, err = tampered.Decrypt("password")
+		assert.Assert(t, errors.Is(err, ErrDecrypt))
+
+		changed, err := ks.ChangePassword("password", "new password", opts)
+		assert.NilError(t, err)
+		assert.Assert(t, changed.Crypto.KDFParams.Salt != ks.Crypto.KDFParams.Salt)
+		_, err = changed.Decrypt("password")
+		assert.Assert(t, errors.Is(err, ErrDecrypt))
+		decrypted, err = changed.Decrypt("new password")
+		assert.NilError(t, err)
+		assert.Assert(t, decrypted.X.Eq(account.X))
+	}
+
+	_, err := EncryptKey(account, "password", KeystoreOptions{KDF: "pbkdf2"})
+	assert.Assert(t, errors.Is(err, ErrInvalidKeystore))
+	_, err = EncryptKey(Account{X: account.X, Y: CreateAccount().Y}, "password", KeystoreOptions{Light: true})
+	assert.Assert(t, errors.Is(err, ErrKeyMismatch))
+}
+
+func TestKeystoreFile(t *testing.T) {
+	account := CreateAccountWithX(testScalar)
+	ks, err := EncryptKey(account, "", KeystoreOptions{Light: true})
+	assert.NilError(t, err)
+
+	path := filepath.Join(t.TempDir(), "account.json")
+	assert.NilError(t, ks.SaveFile(path))
+	loaded, err := LoadKeystoreFile(path)
+	assert.NilError(t, err)
+	assert.DeepEqual(t, loaded, ks)
+
+	// a file asking for an unbounded amount of memory or time.
+	for _, change := range []func(*KDFParams){
+		func(p *KDFParams) { p.N = 1 << 30 },
+		func(p *KDFParams) { p.N = 1<<12 + 1 },
+		func(p *KDFParams) { p.N = 1 },
+		func(p *KDFParams) { p.P = 17 },
+		func(p *KDFParams) { p.P = 0 },
+	} {
+		changed := *loaded
+		change(&changed.Crypto.KDFParams)
+		_, err = changed.Decrypt("")
+		assert.Assert(t, errors.Is(err, ErrInvalidKeystore))
+	}
+	ks, err = EncryptKey(account, "", KeystoreOptions{KDF: KDFArgon2id, Light: true})
+	assert.NilError(t, err)
+	ks.Crypto.KDFParams.Time = 17
+	_, err = ks.Decrypt("")
+	assert.Assert(t, errors.Is(err, ErrInvalidKeystore))
+}
@@ -0,0 +1,55 @@
+package core
+
+import (
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/hpb-project/HCash-SDK/common/types"
+	"github.com/hpb-project/HCash-SDK/core/ebigint"
+)
+
+// messageDomain prefixes every message challenge. The register challenge
+// hashes abi.encode(address, y, K), which starts with 12 zero bytes, so a
+// message signature can never be replayed as a register signature.
+const messageDomain = "HCash Signed Message:\n"
+
+// messageChallenge is keccak256(domain || y || K || keccak256(message)) mod q.
+func messageChallenge(y, K Point, message []byte) *ebigint.NBigInt {
+	yb := parsePoint2ABI_Bytes32_2(y)
+	kb := parsePoint2ABI_Bytes32_2(K)
+	hash := crypto.Keccak256([]byte(messageDomain), yb[0][:], yb[1][:], kb[0][:], kb[1][:], crypto.Keccak256(message))
+	return ebigint.FromBytes(hash).ToRed(b128.Q())
+}
+
+// SignMessage makes a Schnorr signature (c, s) of an arbitrary message with
+// the account key, proving control of y without a transaction. The nonce
+// is derived from x, y and the message as in RFC 6979.
+func SignMessage(keypair Account, message []byte) (*ebigint.NBigInt, *ebigint.NBigInt, error) {
+	y, err := signKey(keypair)
+	if err != nil {
+		return nil, nil, err
+	}
+	yb := parsePoint2ABI_Bytes32_2(y)
+	h := crypto.Keccak256([]byte(messageDomain), yb[0][:], yb[1][:], crypto.Keccak256(message))
+	k := ebigint.ToNBigInt(rfc6979Nonce(b128.Q().Int, keypair.X.Int, h, nil)).ForceRed(b128.Q())
+
+	var c = messageChallenge(y, FixedG().Mul(k), message)
+	var s = c.RedMul(keypair.X).RedAdd(k)
+	return c, s, nil
+}
+
+// VerifyMessage checks a SignMessage signature of message by y.
+func VerifyMessage(y types.Point, message []byte, c, s *ebigint.NBigInt) error {
+	ny, err := b128.DecodePoint(y)
+	if err != nil {
+		return fmt.Errorf("y: %w", err)
+	}
+	if c.Sign() < 0 || c.Cmp(b128.Q().Int) >= 0 {
+		return ErrInvalidSignature
+	}
+	var K = FixedG().Mul(s.ToRed(b128.Q())).Add(ny.Mul(c.ToRed(b128.Q()).RedNeg()))
+	if !messageChallenge(ny, K, message).Eq(c) {
+		return ErrInvalidSignature
+	}
+	return nil
+}
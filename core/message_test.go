@@ -0,0 +1,36 @@
+package core
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/hpb-project/HCash-SDK/common"
+	"github.com/hpb-project/HCash-SDK/core/ebigint"
+	"gotest.tools/assert"
+)
+
+func TestSignMessage(t *testing.T) {
+	nx, _ := new(big.Int).SetString("299569ae0ae1d40140fd8d9afc54d2f581a292fd13fe88c7033d488119bb95b7", 16)
+	account := CreateAccountWithX(ebigint.ToNBigInt(nx).ToRed(b128.Q()))
+	message := []byte("login to example.com")
+
+	c, s, err := SignMessage(account, message)
+	assert.NilError(t, err)
+	assert.NilError(t, VerifyMessage(account.Y, message, c, s))
+
+	c2, s2, _ := SignMessage(account, message)
+	assert.Assert(t, c.Eq(c2) && s.Eq(s2))
+
+	assert.Assert(t, errors.Is(VerifyMessage(account.Y, []byte("login to example.org"), c, s), ErrInvalidSignature))
+	other := CreateAccountWithX(ebigint.NewNBigInt(5).ToRed(b128.Q()))
+	assert.Assert(t, errors.Is(VerifyMessage(other.Y, message, c, s), ErrInvalidSignature))
+
+	// a register signature is not a message signature and the other way round.
+	address := common.FromHex("E4920905e06c6B6070477c40B85756ffDa3cD3E6")
+	rc, rs, err := Sign(address, account)
+	assert.NilError(t, err)
+	assert.Assert(t, errors.Is(VerifyMessage(account.Y, address, rc, rs), ErrInvalidSignature))
+	c, s, _ = SignMessage(account, address)
+	assert.Assert(t, errors.Is(VerifySign(address, account.Y, c, s), ErrInvalidSignature))
+}
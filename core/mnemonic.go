@@ -0,0 +1,225 @@
+package core
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/hpb-project/HCash-SDK/core/ebigint"
+	"golang.org/x/crypto/pbkdf2"
+)
+
+// Mnemonics follow BIP39 with the English wordlist, the seed of a mnemonic
+// is the same as in any BIP39 wallet. The mnemonic and passphrase are used
+// as given, non ASCII passphrases must be NFKD normalized by the caller.
+//
+// Accounts are derived from the seed along a path of hardened indexes, as
+// in SLIP-10 with the group order q of BN128:
+//
+//	I = HMAC-SHA512("HCash seed", seed)                           master
+//	I = HMAC-SHA512(c_parent, 0x00 || ser256(x_parent) || ser32(i)) child i
+//	x = parse256(I[:32]) mod q, c = I[32:]
+//
+// A zero x is skipped by deriving index i+1 instead. Only hardened indexes
+// exist, y does not allow deriving the y of the children.
+const (
+	// DefaultDerivationPath is the parent of the accounts of DeriveAccount,
+	// 269 is the SLIP-44 coin type of HPB.
+	DefaultDerivationPath = "m/44'/269'/0'/0'"
+
+	HardenedKeyStart uint32 = 1 << 31
+
+	seedKey         = "HCash seed"
+	seedIterations  = 2048
+	mnemonicMinBits = 128
+	mnemonicMaxBits = 256
+)
+
+var (
+	ErrInvalidMnemonic = errors.New("invalid mnemonic")
+	ErrInvalidPath     = errors.New("invalid derivation path")
+
+	wordIndex     map[string]int
+	wordIndexOnce sync.Once
+)
+
+func englishIndex(word string) (int, bool) {
+	wordIndexOnce.Do(func() {
+		wordIndex = make(map[string]int, len(englishWordlist))
+		for i, w := range englishWordlist {
+			wordIndex[w] = i
+		}
+	})
+	i, ok := wordIndex[word]
+	return i, ok
+}
+
+// NewMnemonic returns a mnemonic of bits random bits, 128 bits are 12 words
+// and 256 bits are 24 words.
+func NewMnemonic(bits int) (string, error) {
+	return NewMnemonicWithReader(bits, rand.Reader)
+}
+
+func NewMnemonicWithReader(bits int, random io.Reader) (string, error) {
+	if bits < mnemonicMinBits || bits > mnemonicMaxBits || bits%32 != 0 {
+		return "", fmt.Errorf("%w: %d bits of entropy", ErrInvalidMnemonic, bits)
+	}
+	var entropy = make([]byte, bits/8)
+	if _, err := io.ReadFull(random, entropy); err != nil {
+		return "", err
+	}
+	return MnemonicFromEntropy(entropy)
+}
+
+// MnemonicFromEntropy encodes 16 to 32 bytes of entropy, with the first
+// len/4 bits of sha256(entropy) as checksum.
+func MnemonicFromEntropy(entropy []byte) (string, error) {
+	var bits = len(entropy) * 8
+	if bits < mnemonicMinBits || bits > mnemonicMaxBits || bits%32 != 0 {
+		return "", fmt.Errorf("%w: %d bits of entropy", ErrInvalidMnemonic, bits)
+	}
+	var csBits = uint(bits / 32)
+	hash := sha256.Sum256(entropy)
+	var n = new(big.Int).SetBytes(entropy)
+	n.Lsh(n, csBits)
+	n.Or(n, big.NewInt(int64(hash[0]>>(8-csBits))))
+
+	var words = make([]string, (bits+int(csBits))/11)
+	var mask = big.NewInt(2047)
+	for i := len(words) - 1; i >= 0; i-- {
+		words[i] = englishWordlist[new(big.Int).And(n, mask).Int64()]
+		n.Rsh(n, 11)
+	}
+	return strings.Join(words, " "), nil
+}
+
+// MnemonicToEntropy decodes and checks a mnemonic.
+func MnemonicToEntropy(mnemonic string) ([]byte, error) {
+	words := strings.Fields(mnemonic)
+	if len(words) < 12 || len(words) > 24 || len(words)%3 != 0 {
+		return nil, fmt.Errorf("%w: %d words", ErrInvalidMnemonic, len(words))
+	}
+	var n = new(big.Int)
+	for _, w := range words {
+		i, ok := englishIndex(w)
+		if !ok {
+			return nil, fmt.Errorf("%w: unknown word %q", ErrInvalidMnemonic, w)
+		}
+		n.Lsh(n, 11)
+		n.Or(n, big.NewInt(int64(i)))
+	}
+	var csBits = uint(len(words) / 3)
+	var checksum = new(big.Int).And(n, big.NewInt(1<<csBits-1)).Int64()
+	n.Rsh(n, csBits)
+
+	var entropy = make([]byte, (len(words)*11-int(csBits))/8)
+	n.FillBytes(entropy)
+	hash := sha256.Sum256(entropy)
+	if int64(hash[0]>>(8-csBits)) != checksum {
+		return nil, fmt.Errorf("%w: checksum", ErrInvalidMnemonic)
+	}
+	return entropy, nil
+}
+
+func ValidateMnemonic(mnemonic string) error {
+	_, err := MnemonicToEntropy(mnemonic)
+	return err
+}
+
+// MnemonicToSeed checks the mnemonic and returns its 64 bytes BIP39 seed,
+// PBKDF2-HMAC-SHA512 of the words with the salt "mnemonic" || passphrase.
+func MnemonicToSeed(mnemonic string, passphrase string) ([]byte, error) {
+	if err := ValidateMnemonic(mnemonic); err != nil {
+		return nil, err
+	}
+	var normalized = strings.Join(strings.Fields(mnemonic), " ")
+	return pbkdf2.Key([]byte(normalized), []byte("mnemonic"+passphrase), seedIterations, 64, sha512.New), nil
+}
+
+// ParseDerivationPath parses m/a'/b'/..., every index must be hardened.
+func ParseDerivationPath(path string) ([]uint32, error) {
+	parts := strings.Split(strings.TrimSpace(path), "/")
+	if parts[0] != "m" {
+		return nil, fmt.Errorf("%w: %q does not start with m", ErrInvalidPath, path)
+	}
+	var indexes = make([]uint32, 0, len(parts)-1)
+	for _, part := range parts[1:] {
+		if !strings.HasSuffix(part, "'") {
+			return nil, fmt.Errorf("%w: %q is not hardened", ErrInvalidPath, part)
+		}
+		i, err := strconv.ParseUint(strings.TrimSuffix(part, "'"), 10, 31)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %q", ErrInvalidPath, part)
+		}
+		indexes = append(indexes, uint32(i)+HardenedKeyStart)
+	}
+	return indexes, nil
+}
+
+// extendedKey is x with its chain code.
+type extendedKey struct {
+	x     *big.Int
+	chain []byte
+}
+
+func newExtendedKey(key []byte, data []byte) extendedKey {
+	mac := hmac.New(sha512.New, key)
+	mac.Write(data)
+	I := mac.Sum(nil)
+	return extendedKey{x: new(big.Int).Mod(new(big.Int).SetBytes(I[:32]), b128.Q().Int), chain: I[32:]}
+}
+
+func (k extendedKey) child(index uint32) (extendedKey, error) {
+	for {
+		if index < HardenedKeyStart {
+			return extendedKey{}, fmt.Errorf("%w: index %d is not hardened", ErrInvalidPath, index)
+		}
+		var data = make([]byte, 37)
+		k.x.FillBytes(data[1:33])
+		binary.BigEndian.PutUint32(data[33:], index)
+		child := newExtendedKey(k.chain, data)
+		if child.x.Sign() != 0 {
+			return child, nil
+		}
+		index++
+	}
+}
+
+// DerivePath derives the account at path from a seed of 16 to 64 bytes.
+func DerivePath(seed []byte, path string) (Account, error) {
+	if len(seed) < 16 || len(seed) > 64 {
+		return Account{}, fmt.Errorf("%w: seed of %d bytes", ErrInvalidScalar, len(seed))
+	}
+	indexes, err := ParseDerivationPath(path)
+	if err != nil {
+		return Account{}, err
+	}
+	var key = newExtendedKey([]byte(seedKey), seed)
+	if key.x.Sign() == 0 {
+		return Account{}, fmt.Errorf("%w: master key", ErrInvalidScalar)
+	}
+	for _, index := range indexes {
+		if key, err = key.child(index); err != nil {
+			return Account{}, err
+		}
+	}
+	return CreateAccountWithX(ebigint.ToNBigInt(key.x).ForceRed(b128.Q())), nil
+}
+
+// DeriveAccount derives account index under DefaultDerivationPath, a wallet
+// restores its accounts by deriving index 0, 1, ... from the seed.
+func DeriveAccount(seed []byte, index uint32) (Account, error) {
+	if index >= HardenedKeyStart {
+		return Account{}, fmt.Errorf("%w: index %d", ErrInvalidPath, index)
+	}
+	return DerivePath(seed, fmt.Sprintf("%s/%d'", DefaultDerivationPath, index))
+}
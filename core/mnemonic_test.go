@@ -0,0 +1,98 @@
+package core
+
+import (
+	"encoding/hex"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/hpb-project/HCash-SDK/common"
+	"gotest.tools/assert"
+)
+
+// The first vectors of the BIP39 reference implementation, with the
+// passphrase "TREZOR".
+func TestMnemonic(t *testing.T) {
+	var vectors = []struct {
+		entropy  string
+		mnemonic string
+		seed     string
+	}{
+		{
+			"00000000000000000000000000000000",
+			"abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about",
+			"c55257c360c07c72029aebc1b53c05ed0362ada38ead3e3e9efa3708e53495531f09a6987599d18264c1e1c92f2cf141630c7a3c4ab7c81b2f001698e7463b04",
+		},
+		{
+			"7f7f7f7f7f7f7f7f7f7f7f7f7f7f7f7f",
+			"legal winner thank year wave sausage worth useful legal winner thank yellow",
+			"2e8905819b8723fe2c1d161860e5ee1830318dbf49a83bd451cfb8440c28bd6fa457fe1296106559a3c80937a1c1069be3a3a5bd381ee6260e8d9739fce1f607",
+		},
+		{
+			"ffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff",
+			strings.Repeat("zoo ", 23) + "vote",
+			"dd48c104698c30cfe2b6142103248622fb7bb0ff692eebb00089b32d22484e1613912f0a5b694407be899ffd31ed3992c456cdf60f5d4564b8ba3f05a69890ad",
+		},
+	}
+	for _, v := range vectors {
+		entropy, _ := hex.DecodeString(v.entropy)
+		mnemonic, err := MnemonicFromEntropy(entropy)
+		assert.NilError(t, err)
+		assert.Equal(t, mnemonic, v.mnemonic)
+
+		decoded, err := MnemonicToEntropy(mnemonic)
+		assert.NilError(t, err)
+		assert.DeepEqual(t, decoded, entropy)
+
+		seed, err := MnemonicToSeed(mnemonic, "TREZOR")
+		assert.NilError(t, err)
+		assert.Equal(t, hex.EncodeToString(seed), v.seed)
+	}
+
+	mnemonic, err := NewMnemonicWithReader(256, NewDRBG(nil))
+	assert.NilError(t, err)
+	assert.Equal(t, len(strings.Fields(mnemonic)), 24)
+	assert.NilError(t, ValidateMnemonic(mnemonic))
+
+	_, err = NewMnemonic(100)
+	assert.Assert(t, errors.Is(err, ErrInvalidMnemonic))
+	// the last word carries the checksum.
+	err = ValidateMnemonic(strings.Repeat("abandon ", 12))
+	assert.Assert(t, errors.Is(err, ErrInvalidMnemonic))
+	err = ValidateMnemonic(strings.Repeat("abandon ", 11) + "hcash")
+	assert.Assert(t, errors.Is(err, ErrInvalidMnemonic))
+	err = ValidateMnemonic(strings.Repeat("abandon ", 10) + "about")
+	assert.Assert(t, errors.Is(err, ErrInvalidMnemonic))
+}
+
+func TestDeriveAccount(t *testing.T) {
+	seed, err := MnemonicToSeed(strings.Repeat("abandon ", 11)+"about", "")
+	assert.NilError(t, err)
+
+	var expected = []string{
+		"0x064d0cb84a6a01cacb65d61b4f28d0f5ee15b6316531b57348346662632f24c3",
+		"0x26c83c82648df44ffb12dc0be1485eddc87080417290f01f85bb07807391980b",
+	}
+	for i, x := range expected {
+		account, err := DeriveAccount(seed, uint32(i))
+		assert.NilError(t, err)
+		assert.Equal(t, b128.Bytes(account.X.Int), x)
+		assert.Equal(t, account.Y, CreateAccountWithX(account.X).Y)
+
+		same, err := DerivePath(seed, DefaultDerivationPath+"/"+string(rune('0'+i))+"'")
+		assert.NilError(t, err)
+		assert.Assert(t, same.X.Eq(account.X))
+	}
+	master, err := DerivePath(seed, "m")
+	assert.NilError(t, err)
+	assert.Equal(t, b128.Bytes(master.X.Int), "0x2cc5b825814c085bf5a1bc1202a31a7da46df811013da477fae83f3b0d2eba3d")
+
+	_, err = DerivePath(seed, "m/44'/269'/0")
+	assert.Assert(t, errors.Is(err, ErrInvalidPath))
+	_, err = DerivePath(seed, "44'")
+	assert.Assert(t, errors.Is(err, ErrInvalidPath))
+	_, err = DeriveAccount(seed, HardenedKeyStart)
+	assert.Assert(t, errors.Is(err, ErrInvalidPath))
+	_, err = DeriveAccount(common.FromHex("0x01"), 0)
+	assert.Assert(t, errors.Is(err, ErrInvalidScalar))
+}
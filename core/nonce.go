@@ -0,0 +1,61 @@
+package core
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"math/big"
+)
+
+// rfc6979Nonce derives a nonce in [1, q) from the secret x and the message
+// hash h as in RFC 6979 section 3.2, with HMAC-SHA256. extra is the optional
+// additional data of section 3.6, which hedges the nonce with fresh
+// randomness without depending on it.
+func rfc6979Nonce(q, x *big.Int, h []byte, extra []byte) *big.Int {
+	var qlen = q.BitLen()
+	var rlen = (qlen + 7) / 8
+
+	bits2int := func(b []byte) *big.Int {
+		v := new(big.Int).SetBytes(b)
+		if blen := len(b) * 8; blen > qlen {
+			v.Rsh(v, uint(blen-qlen))
+		}
+		return v
+	}
+	int2octets := func(v *big.Int) []byte {
+		return BytePadding(v.Bytes(), rlen)
+	}
+	mac := func(key []byte, data ...[]byte) []byte {
+		m := hmac.New(sha256.New, key)
+		for _, d := range data {
+			m.Write(d)
+		}
+		return m.Sum(nil)
+	}
+
+	var seed = append(int2octets(x), int2octets(new(big.Int).Mod(bits2int(h), q))...)
+	seed = append(seed, extra...)
+
+	var V = make([]byte, sha256.Size)
+	var K = make([]byte, sha256.Size)
+	for i := range V {
+		V[i] = 0x01
+	}
+	K = mac(K, V, []byte{0x00}, seed)
+	V = mac(K, V)
+	K = mac(K, V, []byte{0x01}, seed)
+	V = mac(K, V)
+
+	for {
+		var T []byte
+		for len(T) < rlen {
+			V = mac(K, V)
+			T = append(T, V...)
+		}
+		k := bits2int(T[:rlen])
+		if k.Sign() > 0 && k.Cmp(q) < 0 {
+			return k
+		}
+		K = mac(K, V, []byte{0x00})
+		V = mac(K, V)
+	}
+}
@@ -0,0 +1,26 @@
+package core
+
+import (
+	"crypto/sha256"
+	"math/big"
+	"testing"
+
+	"gotest.tools/assert"
+)
+
+// TestRFC6979Nonce checks the derivation against the P-256, SHA-256 vectors
+// of RFC 6979 A.2.5, it does not depend on the curve.
+func TestRFC6979Nonce(t *testing.T) {
+	q, _ := new(big.Int).SetString("FFFFFFFF00000000FFFFFFFFFFFFFFFFBCE6FAADA7179E84F3B9CAC2FC632551", 16)
+	x, _ := new(big.Int).SetString("C9AFA9D845BA75166B5C215767B1D6934E50C3DB36E89B127B8A622B120F6721", 16)
+
+	h := sha256.Sum256([]byte("sample"))
+	k := rfc6979Nonce(q, x, h[:], nil)
+	assert.Equal(t, k.Text(16), "a6e3c57dd01abe90086538398355dd4c3b17aa873382b0f24d6129493d8aad60")
+
+	h = sha256.Sum256([]byte("test"))
+	k = rfc6979Nonce(q, x, h[:], nil)
+	assert.Equal(t, k.Text(16), "d16b6ae827f17175e040871a1c7ec3500192c4c92677336ec2537acaee0008e0")
+
+	assert.Assert(t, rfc6979Nonce(q, x, h[:], []byte("extra")).Cmp(k) != 0)
+}
@@ -0,0 +1,37 @@
+package core
+
+import (
+	"sync"
+)
+
+// parallelFor runs fn(0) .. fn(n-1) on up to workers goroutines and returns
+// when all of them are done. With workers <= 1 it is a plain loop, fn must
+// only write to its own index when it runs concurrently.
+func parallelFor(workers, n int, fn func(i int)) {
+	if workers <= 1 || n <= 1 {
+		for i := 0; i < n; i++ {
+			fn(i)
+		}
+		return
+	}
+	if workers > n {
+		workers = n
+	}
+
+	var wg sync.WaitGroup
+	var next = make(chan int, n)
+	for i := 0; i < n; i++ {
+		next <- i
+	}
+	close(next)
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range next {
+				fn(i)
+			}
+		}()
+	}
+	wg.Wait()
+}
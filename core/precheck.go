@@ -0,0 +1,181 @@
+package core
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/hpb-project/HCash-SDK/common"
+	"github.com/hpb-project/HCash-SDK/common/types"
+	"github.com/hpb-project/HCash-SDK/core/ebigint"
+)
+
+// checkRing checks that the statement vectors describe one ring whose size
+// is a power of two, as the anonymity proof requires.
+func checkRing(statement TransferStatement) error {
+	var N = len(statement.Y)
+	if N < 2 || N&(N-1) != 0 {
+		return fmt.Errorf("%w: %d", ErrRingSizeNotPowerOfTwo, N)
+	}
+	if len(statement.CLn) != N || len(statement.CRn) != N || len(statement.C) != N {
+		return fmt.Errorf("%w: y %d, CLn %d, CRn %d, C %d", ErrRingSizeMismatch,
+			N, len(statement.CLn), len(statement.CRn), len(statement.C))
+	}
+	return nil
+}
+
+// checkIndex checks that index holds the distinct sender and receiver
+// positions of a ring of N, with opposite parity.
+func checkIndex(index []int, N int) error {
+	if len(index) != 2 {
+		return fmt.Errorf("%w: want 2 indexes, got %d", ErrInvalidIndex, len(index))
+	}
+	for _, i := range index {
+		if i < 0 || i >= N {
+			return fmt.Errorf("%w: %d not in ring of %d", ErrInvalidIndex, i, N)
+		}
+	}
+	if index[0]%2 == index[1]%2 {
+		return fmt.Errorf("%w: %v", ErrParityMismatch, index)
+	}
+	return nil
+}
+
+func checkValue(name string, v int) error {
+	if v < 0 || uint64(v) > uint64(B_MAX) {
+		return fmt.Errorf("%w: %s %d", ErrValueOutOfRange, name, v)
+	}
+	return nil
+}
+
+func decodePoints(name string, points []types.Point) (*GeneratorVector, error) {
+	var gv = make([]Point, len(points))
+	for i, p := range points {
+		var err error
+		if gv[i], err = b128.DecodePoint(p); err != nil {
+			return nil, fmt.Errorf("%s[%d]: %w", name, i, err)
+		}
+	}
+	return NewGeneratorVector(gv), nil
+}
+
+// decodeScalar parses a hex scalar of the witness, it must be non-zero and
+// below the group order.
+func decodeScalar(name, s string) (*ebigint.NBigInt, error) {
+	v, ok := new(big.Int).SetString(common.HexWithout0x(s), 16)
+	if !ok || v.Sign() <= 0 || v.Cmp(b128.Q().Int) >= 0 {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidScalar, name)
+	}
+	return ebigint.ToNBigInt(v).ForceRed(b128.Q()), nil
+}
+
+// checkTransfer checks that the witness opens the statement, which is what
+// the proof is about to show, so that a bad input fails fast instead of
+// producing a proof the contract rejects.
+func checkTransfer(statement *interTransferStatement, witness *interTransferWitness) error {
+	var N = statement.Y.Length()
+	if err := checkIndex(witness.index, N); err != nil {
+		return err
+	}
+	var g = FixedG()
+	var sender = witness.index[0]
+
+	if err := checkKey(witness.key, statement.Y.GetVector()[sender]); err != nil {
+		return fmt.Errorf("%w: y[%d]", err, sender)
+	}
+	if !g.Mul(witness.r).Equal(statement.D) {
+		return fmt.Errorf("%w: D != g^r", ErrCommitmentMismatch)
+	}
+	for i, y := range statement.Y.GetVector() {
+		var value = ebigint.NewNBigInt(0).ToRed(b128.Q())
+		if i == witness.index[0] {
+			value = witness.bTransfer.RedNeg()
+		} else if i == witness.index[1] {
+			value = witness.bTransfer
+		}
+		if !g.Mul(value).Add(y.Mul(witness.r)).Equal(statement.C.GetVector()[i]) {
+			return fmt.Errorf("%w: C[%d]", ErrCommitmentMismatch, i)
+		}
+	}
+
+	var CLn = statement.CLn.GetVector()[sender]
+	var CRn = statement.CRn.GetVector()[sender]
+	return checkBalance(witness.key, CLn, CRn, witness.bDiff)
+}
+
+func checkBurn(statement *interBurnStatement, witness *interBurnWitness) error {
+	if err := checkKey(witness.key, statement.Y); err != nil {
+		return err
+	}
+	return checkBalance(witness.key, statement.CLn, statement.CRn, witness.bDiff)
+}
+
+// checkKey checks that key is the key of y.
+func checkKey(key KeyHandle, y Point) error {
+	pub, err := key.PublicKey()
+	if err != nil {
+		return err
+	}
+	if pub != b128.Serialize(y) {
+		return ErrKeyMismatch
+	}
+	return nil
+}
+
+// checkBalance checks that CLn - sk*CRn = g^bDiff.
+func checkBalance(key KeyHandle, CLn, CRn Point, bDiff *ebigint.NBigInt) error {
+	gB, err := key.Decrypt(b128.Serialize(CLn), b128.Serialize(CRn))
+	if err != nil {
+		return err
+	}
+	if gB != b128.Serialize(FixedG().Mul(bDiff)) {
+		return fmt.Errorf("%w: bDiff %s", ErrBalanceMismatch, bDiff.Text(10))
+	}
+	return nil
+}
+
+// CheckTransfer validates a transfer statement and witness the way
+// ZetherProver.GenerateProof does before it starts proving.
+func CheckTransfer(istatement TransferStatement, iwitness TransferWitness) error {
+	var prover ZetherProver
+	statement, err := prover.toInnerStatement(istatement)
+	if err != nil {
+		return err
+	}
+	witness, err := prover.toWitness(iwitness)
+	if err != nil {
+		return err
+	}
+	return checkTransfer(statement, witness)
+}
+
+// CheckBurn validates a burn statement and witness the way
+// BurnProver.GenerateProof does before it starts proving.
+func CheckBurn(istatement BurnStatement, iwitness BurnWitness) error {
+	var prover BurnProver
+	statement, err := prover.tointerBurnStatement(istatement)
+	if err != nil {
+		return err
+	}
+	witness, err := prover.tointerBurnWitness(iwitness)
+	if err != nil {
+		return err
+	}
+	return checkBurn(statement, witness)
+}
+
+// CheckNonce checks that u is the nonce gEpoch^sk the contract expects
+// along with a proof of the same epoch and secret.
+func CheckNonce(epoch int, sk string, u types.Point) error {
+	x, err := decodeScalar("sk", sk)
+	if err != nil {
+		return err
+	}
+	nu, err := b128.DecodePoint(u)
+	if err != nil {
+		return fmt.Errorf("u: %w", err)
+	}
+	if !U(epoch, x).Equal(nu) {
+		return ErrNonceMismatch
+	}
+	return nil
+}
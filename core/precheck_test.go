@@ -0,0 +1,103 @@
+package core
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/hpb-project/HCash-SDK/common/types"
+	"github.com/hpb-project/HCash-SDK/core/ebigint"
+	"gotest.tools/assert"
+)
+
+func TestCheckTransfer(t *testing.T) {
+	istatement, iwitness := transferVector()
+	assert.NilError(t, CheckTransfer(istatement, iwitness))
+
+	var cases = []struct {
+		name   string
+		modify func(s *TransferStatement, w *TransferWitness)
+		err    error
+	}{
+		{"ring of 3", func(s *TransferStatement, w *TransferWitness) {
+			s.Y = append(s.Y, s.Y[0])
+		}, ErrRingSizeNotPowerOfTwo},
+		{"short C", func(s *TransferStatement, w *TransferWitness) {
+			s.C = s.C[:1]
+		}, ErrRingSizeMismatch},
+		{"one index", func(s *TransferStatement, w *TransferWitness) {
+			w.Index = []int{1}
+		}, ErrInvalidIndex},
+		{"index out of ring", func(s *TransferStatement, w *TransferWitness) {
+			w.Index = []int{1, 2}
+		}, ErrInvalidIndex},
+		{"same parity", func(s *TransferStatement, w *TransferWitness) {
+			w.Index = []int{1, 1}
+		}, ErrParityMismatch},
+		{"negative diff", func(s *TransferStatement, w *TransferWitness) {
+			w.BDiff = -1
+		}, ErrInsufficientBalance},
+		{"negative value", func(s *TransferStatement, w *TransferWitness) {
+			w.BTransfer = -1
+		}, ErrValueOutOfRange},
+		{"off curve", func(s *TransferStatement, w *TransferWitness) {
+			copy(s.CLn[0][32:], s.CLn[1][32:])
+		}, ErrInvalidPoint},
+		{"bad sk", func(s *TransferStatement, w *TransferWitness) {
+			w.SK = "0x"
+		}, ErrInvalidScalar},
+		{"wrong sender", func(s *TransferStatement, w *TransferWitness) {
+			w.Index = []int{0, 1}
+		}, ErrKeyMismatch},
+		{"wrong r", func(s *TransferStatement, w *TransferWitness) {
+			w.R = "0x01"
+		}, ErrCommitmentMismatch},
+		{"wrong value", func(s *TransferStatement, w *TransferWitness) {
+			w.BTransfer++
+		}, ErrCommitmentMismatch},
+		{"wrong diff", func(s *TransferStatement, w *TransferWitness) {
+			w.BDiff++
+		}, ErrBalanceMismatch},
+	}
+	for _, c := range cases {
+		s, w := transferVector()
+		c.modify(&s, &w)
+		err := CheckTransfer(s, w)
+		assert.Assert(t, errors.Is(err, c.err), "%s: %v", c.name, err)
+
+		_, err = ProveTransfer(s, w)
+		assert.Assert(t, errors.Is(err, c.err), "%s: %v", c.name, err)
+	}
+}
+
+func TestCheckBurn(t *testing.T) {
+	istatement, iwitness := burnVector()
+	assert.NilError(t, CheckBurn(istatement, iwitness))
+
+	w := iwitness
+	w.BDiff = 98
+	_, err := ProveBurn(istatement, w)
+	assert.Assert(t, errors.Is(err, ErrBalanceMismatch), err)
+
+	w.BDiff = -1
+	assert.Assert(t, errors.Is(CheckBurn(istatement, w), ErrInsufficientBalance))
+
+	w = iwitness
+	w.SK = "0x05"
+	assert.Assert(t, errors.Is(CheckBurn(istatement, w), ErrKeyMismatch))
+
+	s := istatement
+	s.Y = types.Point{}
+	s.Y[31], s.Y[63] = 1, 1
+	assert.Assert(t, errors.Is(CheckBurn(s, iwitness), ErrInvalidPoint))
+
+	s = istatement
+	s.Sender = "0x1234"
+	assert.ErrorContains(t, CheckBurn(s, iwitness), "invalid sender address")
+}
+
+func TestCheckNonce(t *testing.T) {
+	_, iwitness := transferVector()
+	u := b128.Serialize(U(53687137, ebigint.FromHex(iwitness.SK)))
+	assert.NilError(t, CheckNonce(53687137, iwitness.SK, u))
+	assert.Assert(t, errors.Is(CheckNonce(53687138, iwitness.SK, u), ErrNonceMismatch))
+}
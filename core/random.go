@@ -0,0 +1,78 @@
+package core
+
+import (
+	"crypto/rand"
+	"encoding/binary"
+	"io"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/hpb-project/HCash-SDK/core/ebigint"
+)
+
+// RandomScalarFrom reads a uniformly random, non-zero scalar mod q from r.
+func RandomScalarFrom(r io.Reader) (*ebigint.NBigInt, error) {
+	for {
+		k, err := rand.Int(r, b128.Q().Int)
+		if err != nil {
+			return nil, err
+		}
+		if k.Sign() > 0 {
+			return ebigint.ToNBigInt(k).ForceRed(b128.Q()), nil
+		}
+	}
+}
+
+// scalarReader draws the blinding scalars of a proof from one source and
+// keeps the first read error, so that a prover checks it once at the end.
+type scalarReader struct {
+	r   io.Reader
+	err error
+}
+
+func newScalarReader(r io.Reader) *scalarReader {
+	if r == nil {
+		r = rand.Reader
+	}
+	return &scalarReader{r: r}
+}
+
+func (s *scalarReader) next() *ebigint.NBigInt {
+	if s.err == nil {
+		k, err := RandomScalarFrom(s.r)
+		if err == nil {
+			return k
+		}
+		s.err = err
+	}
+	return ebigint.NewNBigInt(0).ToRed(b128.Q())
+}
+
+// DRBG is a deterministic random bit generator, the stream is
+// keccak256(seed || counter) for counter = 0, 1, ... It makes proofs and
+// signatures reproducible for test vectors and must never be used for real
+// keys or proofs.
+type DRBG struct {
+	seed    []byte
+	counter uint64
+	block   []byte
+}
+
+func NewDRBG(seed []byte) *DRBG {
+	return &DRBG{seed: append([]byte{}, seed...)}
+}
+
+func (d *DRBG) Read(p []byte) (int, error) {
+	var n int
+	for n < len(p) {
+		if len(d.block) == 0 {
+			var counter [8]byte
+			binary.BigEndian.PutUint64(counter[:], d.counter)
+			d.block = crypto.Keccak256(d.seed, counter[:])
+			d.counter++
+		}
+		c := copy(p[n:], d.block)
+		d.block = d.block[c:]
+		n += c
+	}
+	return n, nil
+}
@@ -0,0 +1,79 @@
+package core
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/hpb-project/HCash-SDK/core/ebigint"
+	"gotest.tools/assert"
+)
+
+// repeatReader returns the same bytes on every read. The fixed proof vectors
+// were generated with every blinding scalar set to testScalar, which is what
+// RandomScalarFrom draws from repeatReader(testScalar.Bytes()).
+type repeatReader []byte
+
+func (r repeatReader) Read(p []byte) (int, error) {
+	for i := range p {
+		p[i] = r[i%len(r)]
+	}
+	return len(p), nil
+}
+
+var testScalar = ebigint.FromHex("c3f4db6cd90e04d6e086f73fdb7a4ccaa4f57e48593d80c11c0fdd1fcac348df").ToRed(b128.Q())
+
+func testRandom() io.Reader {
+	var b = make([]byte, 32)
+	testScalar.FillBytes(b)
+	return repeatReader(b)
+}
+
+type failingReader struct{}
+
+func (failingReader) Read(p []byte) (int, error) {
+	return 0, errors.New("no entropy")
+}
+
+func TestRandomScalarFrom(t *testing.T) {
+	k, err := RandomScalarFrom(testRandom())
+	assert.NilError(t, err)
+	assert.Assert(t, k.Eq(testScalar))
+
+	_, err = RandomScalarFrom(failingReader{})
+	assert.ErrorContains(t, err, "no entropy")
+}
+
+func TestDRBG(t *testing.T) {
+	var a, b = make([]byte, 100), make([]byte, 100)
+	NewDRBG([]byte("seed")).Read(a)
+	d := NewDRBG([]byte("seed"))
+	d.Read(b[:7])
+	d.Read(b[7:])
+	assert.Assert(t, bytes.Equal(a, b))
+
+	NewDRBG([]byte("other seed")).Read(b)
+	assert.Assert(t, !bytes.Equal(a, b))
+}
+
+func TestProveWithReader(t *testing.T) {
+	istatement, iwitness := transferVector()
+	prove := func(seed string) string {
+		proof, err := ProveTransferWithReader(istatement, iwitness, 1, NewDRBG([]byte(seed)))
+		assert.NilError(t, err)
+		return proof
+	}
+	assert.Equal(t, prove("transfer"), prove("transfer"))
+	assert.Assert(t, prove("transfer") != prove("other"))
+	_, err := ProveTransferWithReader(istatement, iwitness, 1, failingReader{})
+	assert.ErrorContains(t, err, "no entropy")
+
+	bstatement, bwitness := burnVector()
+	proof, err := ProveBurnWithReader(bstatement, bwitness, NewDRBG([]byte("burn")))
+	assert.NilError(t, err)
+	again, _ := ProveBurnWithReader(bstatement, bwitness, NewDRBG([]byte("burn")))
+	assert.Equal(t, again, proof)
+	_, err = ProveBurnWithReader(bstatement, bwitness, failingReader{})
+	assert.ErrorContains(t, err, "no entropy")
+}
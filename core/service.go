@@ -1,21 +1,67 @@
 package core
 
-func ProveTransfer(statement TransferStatement, witness TransferWitness) string {
+import (
+	"crypto/rand"
+	"io"
+
+	"github.com/hpb-project/HCash-SDK/common/types"
+)
+
+// ProveTransfer returns the serialized transfer proof. The input is checked
+// as CheckTransfer does, the errors can be tested with errors.Is.
+func ProveTransfer(statement TransferStatement, witness TransferWitness) (string, error) {
+	return ProveTransferWithWorkers(statement, witness, 1)
+}
+
+// ProveTransferWithWorkers is ProveTransfer with the commitments spread over
+// workers goroutines, the proof is the same for any number of workers.
+func ProveTransferWithWorkers(statement TransferStatement, witness TransferWitness, workers int) (string, error) {
+	return ProveTransferWithReader(statement, witness, workers, rand.Reader)
+}
+
+// ProveTransferWithReader is ProveTransferWithWorkers with the blinding
+// scalars read from random instead of crypto/rand.
+func ProveTransferWithReader(statement TransferStatement, witness TransferWitness, workers int, random io.Reader) (string, error) {
 	zether := NewZetherProver()
+	zether.SetWorkers(workers)
+	zether.SetRandom(random)
 	//statement.Content()
 	//witness.Content()
-	proof := zether.GenerateProof(statement, witness)
-	if proof == nil {
-		return ""
+	proof, err := zether.GenerateProof(statement, witness)
+	if err != nil {
+		return "", err
 	}
-	return proof.Serialize()
+	return proof.Serialize(), nil
 }
 
-func ProveBurn(statement BurnStatement, witness BurnWitness) string {
+// ProveBurn returns the serialized burn proof. The input is checked as
+// CheckBurn does.
+func ProveBurn(statement BurnStatement, witness BurnWitness) (string, error) {
+	return ProveBurnWithReader(statement, witness, rand.Reader)
+}
+
+// ProveBurnWithReader is ProveBurn with the blinding scalars read from
+// random instead of crypto/rand.
+func ProveBurnWithReader(statement BurnStatement, witness BurnWitness, random io.Reader) (string, error) {
 	burn := NewBurnProver()
-	proof := burn.GenerateProof(statement, witness)
-	if proof == nil {
-		return ""
+	burn.SetRandom(random)
+	proof, err := burn.GenerateProof(statement, witness)
+	if err != nil {
+		return "", err
 	}
-	return proof.Serialize()
+	return proof.Serialize(), nil
+}
+
+// VerifyTransfer checks a serialized transfer proof against its statement
+// and nonce u the same way ZetherVerifier.sol does, nil means valid.
+func VerifyTransfer(statement TransferStatement, u types.Point, proof string) error {
+	zether := NewZetherVerifier()
+	return zether.Verify(statement, u, proof)
+}
+
+// VerifyBurn checks a serialized burn proof the same way BurnVerifier.sol
+// does, nil means valid.
+func VerifyBurn(statement BurnStatement, u types.Point, proof string) error {
+	burn := NewBurnVerifier()
+	return burn.Verify(statement, u, proof)
 }
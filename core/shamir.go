@@ -0,0 +1,178 @@
+package core
+
+import (
+	"crypto/rand"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/hpb-project/HCash-SDK/common"
+	"github.com/hpb-project/HCash-SDK/common/types"
+	"github.com/hpb-project/HCash-SDK/core/ebigint"
+)
+
+// A KeyShare is the point (index, f(index)) of a random polynomial f of
+// degree threshold-1 over Z_q with f(0) = x. Any threshold shares give x
+// back, fewer tell nothing about it. The share carries y so that the
+// recovered x is checked against y = g^x, and a checksum against typos.
+type KeyShare struct {
+	Y         types.Point
+	Threshold int
+	Index     int
+	Value     *ebigint.NBigInt
+}
+
+// MaxKeyShares is the largest n, indexes are 1..n.
+const MaxKeyShares = 255
+
+var ErrInvalidShare = errors.New("invalid key share")
+
+// checksum is the first 4 bytes of keccak256(y || threshold || index || value).
+func (s KeyShare) checksum() string {
+	var head [4]byte
+	binary.BigEndian.PutUint16(head[:2], uint16(s.Threshold))
+	binary.BigEndian.PutUint16(head[2:], uint16(s.Index))
+	var value = make([]byte, 32)
+	if s.Value != nil {
+		s.Value.FillBytes(value)
+	}
+	return "0x" + hex.EncodeToString(crypto.Keccak256(s.Y.Bytes(), head[:], value)[:4])
+}
+
+func (s KeyShare) check() error {
+	if s.Threshold < 2 || s.Threshold > MaxKeyShares || s.Index < 1 || s.Index > MaxKeyShares {
+		return fmt.Errorf("%w: threshold %d, index %d", ErrInvalidShare, s.Threshold, s.Index)
+	}
+	if s.Value == nil || s.Value.Sign() < 0 || s.Value.Cmp(b128.Q().Int) >= 0 {
+		return fmt.Errorf("%w: value of share %d", ErrInvalidShare, s.Index)
+	}
+	return nil
+}
+
+func (s KeyShare) MarshalJSON() ([]byte, error) {
+	type pKeyShare struct {
+		Y         types.Point `json:"y"`
+		Threshold int         `json:"threshold"`
+		Index     int         `json:"index"`
+		Value     string      `json:"value"`
+		Checksum  string      `json:"checksum"`
+	}
+	if err := s.check(); err != nil {
+		return nil, err
+	}
+	return json.Marshal(pKeyShare{Y: s.Y, Threshold: s.Threshold, Index: s.Index, Value: b128.Bytes(s.Value.Int), Checksum: s.checksum()})
+}
+
+// UnmarshalJSON fails with ErrInvalidShare on a wrong checksum.
+func (s *KeyShare) UnmarshalJSON(input []byte) error {
+	type pKeyShare struct {
+		Y         types.Point `json:"y"`
+		Threshold int         `json:"threshold"`
+		Index     int         `json:"index"`
+		Value     string      `json:"value"`
+		Checksum  string      `json:"checksum"`
+	}
+	var p pKeyShare
+	if err := json.Unmarshal(input, &p); err != nil {
+		return err
+	}
+	v, ok := new(big.Int).SetString(common.HexWithout0x(p.Value), 16)
+	if !ok {
+		return fmt.Errorf("%w: value", ErrInvalidShare)
+	}
+	share := KeyShare{Y: p.Y, Threshold: p.Threshold, Index: p.Index, Value: ebigint.ToNBigInt(v).ForceRed(b128.Q())}
+	if err := share.check(); err != nil {
+		return err
+	}
+	if share.checksum() != p.Checksum {
+		return fmt.Errorf("%w: checksum of share %d", ErrInvalidShare, p.Index)
+	}
+	*s = share
+	return nil
+}
+
+// SplitAccount splits x into n shares, any threshold of them recover it.
+func SplitAccount(account Account, threshold, n int) ([]KeyShare, error) {
+	return SplitAccountWithReader(account, threshold, n, rand.Reader)
+}
+
+func SplitAccountWithReader(account Account, threshold, n int, random io.Reader) ([]KeyShare, error) {
+	if threshold < 2 || n < threshold || n > MaxKeyShares {
+		return nil, fmt.Errorf("%w: %d of %d shares", ErrInvalidShare, threshold, n)
+	}
+	if _, err := signKey(account); err != nil {
+		return nil, err
+	}
+	var coefficients = make([]*ebigint.NBigInt, threshold)
+	coefficients[0] = account.X.ToRed(b128.Q())
+	scalars := newScalarReader(random)
+	for i := 1; i < threshold; i++ {
+		coefficients[i] = scalars.next()
+	}
+	if scalars.err != nil {
+		return nil, scalars.err
+	}
+
+	var shares = make([]KeyShare, n)
+	for i := range shares {
+		index := ebigint.NewNBigInt(int64(i + 1)).ToRed(b128.Q())
+		// Horner's rule from the highest coefficient.
+		value := coefficients[threshold-1]
+		for j := threshold - 2; j >= 0; j-- {
+			value = value.RedMul(index).RedAdd(coefficients[j])
+		}
+		shares[i] = KeyShare{Y: account.Y, Threshold: threshold, Index: i + 1, Value: value}
+	}
+	return shares, nil
+}
+
+// CombineShares recovers the account from at least threshold shares of it,
+// ErrKeyMismatch means the shares do not interpolate to the x of y.
+func CombineShares(shares []KeyShare) (Account, error) {
+	if len(shares) == 0 {
+		return Account{}, fmt.Errorf("%w: no shares", ErrInvalidShare)
+	}
+	var first = shares[0]
+	var seen = make(map[int]bool)
+	for _, s := range shares {
+		if err := s.check(); err != nil {
+			return Account{}, err
+		}
+		if s.Y != first.Y || s.Threshold != first.Threshold {
+			return Account{}, fmt.Errorf("%w: share %d is of another split", ErrInvalidShare, s.Index)
+		}
+		if seen[s.Index] {
+			return Account{}, fmt.Errorf("%w: share %d twice", ErrInvalidShare, s.Index)
+		}
+		seen[s.Index] = true
+	}
+	if len(shares) < first.Threshold {
+		return Account{}, fmt.Errorf("%w: %d of %d shares", ErrInvalidShare, len(shares), first.Threshold)
+	}
+
+	// Lagrange interpolation at 0 over the first threshold shares.
+	shares = shares[:first.Threshold]
+	var x = ebigint.NewNBigInt(0).ToRed(b128.Q())
+	for i, si := range shares {
+		num := ebigint.NewNBigInt(1).ToRed(b128.Q())
+		den := ebigint.NewNBigInt(1).ToRed(b128.Q())
+		for j, sj := range shares {
+			if i == j {
+				continue
+			}
+			num = num.RedMul(ebigint.NewNBigInt(int64(sj.Index)).ToRed(b128.Q()))
+			den = den.RedMul(ebigint.NewNBigInt(int64(sj.Index - si.Index)).ToRed(b128.Q()))
+		}
+		x = x.RedAdd(si.Value.ToRed(b128.Q()).RedMul(num).RedMul(den.RedInvm()))
+	}
+	var account = Account{X: x, Y: first.Y}
+	if _, err := signKey(account); err != nil {
+		return Account{}, err
+	}
+	return account, nil
+}
@@ -0,0 +1,64 @@
+package core
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"gotest.tools/assert"
+)
+
+func TestSplitAccount(t *testing.T) {
+	account := CreateAccountWithX(testScalar)
+	shares, err := SplitAccountWithReader(account, 3, 5, NewDRBG([]byte("shamir")))
+	assert.NilError(t, err)
+	assert.Equal(t, len(shares), 5)
+
+	// every 3 of the 5 shares, in any order.
+	for _, set := range [][]int{{0, 1, 2}, {4, 2, 0}, {1, 3, 4}, {3, 4, 0, 1}} {
+		var subset []KeyShare
+		for _, i := range set {
+			subset = append(subset, shares[i])
+		}
+		recovered, err := CombineShares(subset)
+		assert.NilError(t, err)
+		assert.Assert(t, recovered.X.Eq(account.X))
+		assert.Equal(t, recovered.Y, account.Y)
+	}
+
+	_, err = CombineShares(shares[:2])
+	assert.Assert(t, errors.Is(err, ErrInvalidShare))
+	_, err = CombineShares([]KeyShare{shares[0], shares[1], shares[1]})
+	assert.Assert(t, errors.Is(err, ErrInvalidShare))
+
+	// a share of another split of the same account.
+	other, err := SplitAccount(account, 3, 5)
+	assert.NilError(t, err)
+	_, err = CombineShares([]KeyShare{shares[0], shares[1], other[2]})
+	assert.Assert(t, errors.Is(err, ErrKeyMismatch))
+
+	_, err = SplitAccount(account, 1, 5)
+	assert.Assert(t, errors.Is(err, ErrInvalidShare))
+	_, err = SplitAccount(account, 3, 256)
+	assert.Assert(t, errors.Is(err, ErrInvalidShare))
+}
+
+func TestKeyShareJSON(t *testing.T) {
+	shares, err := SplitAccountWithReader(CreateAccountWithX(testScalar), 2, 2, NewDRBG(nil))
+	assert.NilError(t, err)
+	data, err := json.Marshal(shares[1])
+	assert.NilError(t, err)
+	var share KeyShare
+	assert.NilError(t, json.Unmarshal(data, &share))
+	assert.DeepEqual(t, share.Index, shares[1].Index)
+	assert.Assert(t, share.Value.Eq(shares[1].Value))
+
+	// a typo in the value.
+	var raw map[string]interface{}
+	assert.NilError(t, json.Unmarshal(data, &raw))
+	value := []byte(raw["value"].(string))
+	value[10] ^= 1
+	raw["value"] = string(value)
+	data, _ = json.Marshal(raw)
+	assert.Assert(t, errors.Is(json.Unmarshal(data, &share), ErrInvalidShare))
+}
@@ -0,0 +1,670 @@
+package core
+
+import (
+	"bytes"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"net"
+	"net/rpc"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/hpb-project/HCash-SDK/common/types"
+	"github.com/hpb-project/HCash-SDK/core/ebigint"
+)
+
+// A 2-of-2 threshold account has x = x1 + x2 and y = g^x1 + g^x2, party 1
+// holds x1 and party 2 holds x2, nobody ever holds x. Party 1 proves and
+// signs with a ThresholdKey, a KeyHandle that asks party 2, a
+// ThresholdPeer, for its part of every operation that needs x:
+//
+//	u        = GEpoch^x1 + GEpoch^x2
+//	CL - CR^x = CL - CR^x1 - CR^x2
+//	A        = base^k1 + base^k2, party 2 commits to base^k2 first
+//	s        = (k1 + c*x1) + (k2 + c*x2)
+//
+// The register and message signatures are the same Commit and Respond with
+// the base g. Key generation (RunThresholdDKG) is commit and reveal with a
+// proof of possession of every share, so neither party can choose its y
+// after seeing the other one.
+//
+// Every Commit and Decrypt of party 1 carries its purpose, a kind and the
+// data behind it. Party 2 passes the purpose to its approval hook (see
+// ThresholdPeer.SetApprove) before it answers a Decrypt or a Respond, so
+// that it takes part in what it approves only. The challenge of a sign or
+// message purpose is checked against the data, party 2 signs exactly that.
+// The challenge of a proof is not, party 2 then relies on the statement
+// party 1 claims to prove.
+const thresholdShareMessage = "HCash 2-of-2 key share"
+
+// The kinds of ThresholdPurpose.
+const (
+	ThresholdDecrypt = "decrypt" // data is CL || CR
+	ThresholdSign    = "sign"    // data is the ZSC address
+	ThresholdMessage = "message" // data is the message
+	ThresholdProve   = "prove"   // data is the statement, e.g. EncodeBurnStatement
+)
+
+// ThresholdPurpose is what party 1 asks the share of party 2 for.
+type ThresholdPurpose struct {
+	Kind string
+	Data []byte
+}
+
+var ErrThresholdProtocol = errors.New("threshold protocol failure")
+
+// ThresholdShare is the share of one party and the joint public key.
+type ThresholdShare struct {
+	Share Account     `json:"share"`
+	Y     types.Point `json:"y"`
+}
+
+// ThresholdTransport carries the calls of party 1 to party 2, *rpc.Client
+// is one. Method names are those of ThresholdPeer.
+type ThresholdTransport interface {
+	Call(serviceMethod string, args interface{}, reply interface{}) error
+}
+
+// ThresholdProof is a share public key with the proof of possession of its
+// secret, a message signature of thresholdShareMessage.
+type ThresholdProof struct {
+	Y types.Point
+	C *big.Int
+	S *big.Int
+}
+
+func newThresholdProof(share Account) (ThresholdProof, error) {
+	c, s, err := SignMessage(share, []byte(thresholdShareMessage))
+	if err != nil {
+		return ThresholdProof{}, err
+	}
+	return ThresholdProof{Y: share.Y, C: c.Int, S: s.Int}, nil
+}
+
+func (p ThresholdProof) verify() error {
+	if p.C == nil || p.S == nil {
+		return ErrInvalidSignature
+	}
+	return VerifyMessage(p.Y, []byte(thresholdShareMessage), ebigint.ToNBigInt(p.C), ebigint.ToNBigInt(p.S))
+}
+
+func (p ThresholdProof) digest() []byte {
+	var c, s = make([]byte, 32), make([]byte, 32)
+	p.C.FillBytes(c)
+	p.S.FillBytes(s)
+	return crypto.Keccak256(p.Y.Bytes(), c, s)
+}
+
+// pointsDigest is the commitment of party 2 to its nonce points.
+func pointsDigest(points []types.Point) []byte {
+	var data = make([][]byte, len(points))
+	for i, p := range points {
+		data[i] = p.Bytes()
+	}
+	return crypto.Keccak256(data...)
+}
+
+func addPoints(a, b types.Point) (types.Point, error) {
+	pa, err := b128.DecodePoint(a)
+	if err != nil {
+		return types.Point{}, err
+	}
+	pb, err := b128.DecodePoint(b)
+	if err != nil {
+		return types.Point{}, err
+	}
+	return b128.Serialize(pa.Add(pb)), nil
+}
+
+// ThresholdCommit are the bases of ThresholdPeer.Commit.
+type ThresholdCommit struct {
+	Bases   []types.Point
+	Purpose ThresholdPurpose
+}
+
+// ThresholdDecryptArgs is the CR of ThresholdPeer.Decrypt.
+type ThresholdDecryptArgs struct {
+	CR      types.Point
+	Purpose ThresholdPurpose
+}
+
+// ThresholdCommitment is the answer of ThresholdPeer.Commit.
+type ThresholdCommitment struct {
+	Session uint64
+	Digest  []byte
+}
+
+// ThresholdReveal are the nonce points of party 1 for a session.
+type ThresholdReveal struct {
+	Session uint64
+	Points  []types.Point
+}
+
+type peerSession struct {
+	k        *ebigint.NBigInt
+	bases    []types.Point
+	points   []types.Point
+	purpose  ThresholdPurpose
+	theirs   []types.Point // the points of party 1
+	revealed bool
+}
+
+// bound checks that c is the challenge of a sign or message purpose, with
+// the joint nonce of the session.
+func (this *peerSession) bound(y types.Point, c *big.Int) error {
+	var kind = this.purpose.Kind
+	if kind != ThresholdSign && kind != ThresholdMessage {
+		return nil
+	}
+	if len(this.bases) != 1 || this.bases[0] != b128.Serialize(FixedG().Point()) {
+		return fmt.Errorf("%w: %s is not signed with the base g", ErrThresholdProtocol, kind)
+	}
+	K, err := addPoints(this.theirs[0], this.points[0])
+	if err != nil {
+		return err
+	}
+	nK, err := b128.DecodePoint(K)
+	if err != nil {
+		return err
+	}
+	ny, err := b128.DecodePoint(y)
+	if err != nil {
+		return err
+	}
+	var want *ebigint.NBigInt
+	if kind == ThresholdSign {
+		if want, err = signChallenge(this.purpose.Data, ny, nK); err != nil {
+			return err
+		}
+	} else {
+		want = messageChallenge(ny, nK, this.purpose.Data)
+	}
+	if want.Cmp(c) != 0 {
+		return fmt.Errorf("%w: challenge is not the one of the %s", ErrThresholdProtocol, kind)
+	}
+	return nil
+}
+
+// ThresholdPeer is party 2, it is served to party 1 with net/rpc, see
+// ServeThreshold and NewLoopbackTransport.
+type ThresholdPeer struct {
+	random  io.Reader
+	approve func(kind string, data []byte) error
+
+	lock     sync.Mutex
+	share    *ThresholdShare
+	dkg      []byte // the commitment of party 1
+	dkgShare Account
+	next     uint64
+	sessions map[uint64]*peerSession
+}
+
+// NewThresholdPeer returns a party 2 without share, it gets one with the
+// key generation of party 1.
+func NewThresholdPeer(random io.Reader) *ThresholdPeer {
+	if random == nil {
+		random = rand.Reader
+	}
+	return &ThresholdPeer{random: random, sessions: make(map[uint64]*peerSession)}
+}
+
+// NewThresholdPeerWithShare returns a party 2 restored from its share.
+func NewThresholdPeerWithShare(share ThresholdShare, random io.Reader) (*ThresholdPeer, error) {
+	if _, err := signKey(share.Share); err != nil {
+		return nil, err
+	}
+	peer := NewThresholdPeer(random)
+	peer.share = &share
+	return peer, nil
+}
+
+// SetApprove sets the hook asked before every answer that uses x2, with
+// the purpose party 1 gives, see ThresholdPurpose. An error refuses the
+// request and goes back to party 1. Without a hook party 2 answers
+// everything, which leaves the account to party 1 alone.
+func (this *ThresholdPeer) SetApprove(approve func(kind string, data []byte) error) {
+	this.lock.Lock()
+	defer this.lock.Unlock()
+	this.approve = approve
+}
+
+func (this *ThresholdPeer) check(purpose ThresholdPurpose) error {
+	this.lock.Lock()
+	approve := this.approve
+	this.lock.Unlock()
+	if approve == nil {
+		return nil
+	}
+	if err := approve(purpose.Kind, purpose.Data); err != nil {
+		return fmt.Errorf("%s not approved: %w", purpose.Kind, err)
+	}
+	return nil
+}
+
+// Share returns the share of party 2 to be stored, after key generation.
+func (this *ThresholdPeer) Share() (ThresholdShare, error) {
+	this.lock.Lock()
+	defer this.lock.Unlock()
+	if this.share == nil {
+		return ThresholdShare{}, fmt.Errorf("%w: no share", ErrThresholdProtocol)
+	}
+	return *this.share, nil
+}
+
+func (this *ThresholdPeer) account() (ThresholdShare, error) {
+	this.lock.Lock()
+	defer this.lock.Unlock()
+	if this.share == nil {
+		return ThresholdShare{}, fmt.Errorf("%w: no share", ErrThresholdProtocol)
+	}
+	return *this.share, nil
+}
+
+// DKGCommit takes the commitment of party 1 and answers the share of
+// party 2 with its proof.
+func (this *ThresholdPeer) DKGCommit(commitment []byte, reply *ThresholdProof) error {
+	this.lock.Lock()
+	defer this.lock.Unlock()
+	if this.share != nil || this.dkg != nil {
+		return fmt.Errorf("%w: key generation already done", ErrThresholdProtocol)
+	}
+	share, err := CreateAccountWithReader(this.random)
+	if err != nil {
+		return err
+	}
+	proof, err := newThresholdProof(share)
+	if err != nil {
+		return err
+	}
+	this.dkg, this.dkgShare = commitment, share
+	*reply = proof
+	return nil
+}
+
+// DKGReveal checks the share of party 1 against its commitment and answers
+// the joint y.
+func (this *ThresholdPeer) DKGReveal(proof ThresholdProof, reply *types.Point) error {
+	this.lock.Lock()
+	defer this.lock.Unlock()
+	if this.share != nil || this.dkg == nil {
+		return fmt.Errorf("%w: no key generation pending", ErrThresholdProtocol)
+	}
+	if err := proof.verify(); err != nil {
+		return err
+	}
+	if !bytes.Equal(proof.digest(), this.dkg) {
+		return fmt.Errorf("%w: share does not match its commitment", ErrThresholdProtocol)
+	}
+	y, err := addPoints(proof.Y, this.dkgShare.Y)
+	if err != nil {
+		return err
+	}
+	this.share = &ThresholdShare{Share: this.dkgShare, Y: y}
+	this.dkg, this.dkgShare = nil, Account{}
+	*reply = y
+	return nil
+}
+
+// U answers GEpoch^x2.
+func (this *ThresholdPeer) U(epoch int, reply *types.Point) error {
+	share, err := this.account()
+	if err != nil {
+		return err
+	}
+	*reply = b128.Serialize(U(epoch, share.Share.X))
+	return nil
+}
+
+// Decrypt answers the decryption share CR^x2, once approved.
+func (this *ThresholdPeer) Decrypt(args ThresholdDecryptArgs, reply *types.Point) error {
+	share, err := this.account()
+	if err != nil {
+		return err
+	}
+	p, err := b128.DecodePoint(args.CR)
+	if err != nil {
+		return err
+	}
+	if err := this.check(args.Purpose); err != nil {
+		return err
+	}
+	*reply = b128.Serialize(p.Mul(share.Share.X))
+	return nil
+}
+
+// Commit draws k2 and answers the digest of base^k2 for every base.
+func (this *ThresholdPeer) Commit(args ThresholdCommit, reply *ThresholdCommitment) error {
+	if _, err := this.account(); err != nil {
+		return err
+	}
+	var points = make([]Point, len(args.Bases))
+	for i, base := range args.Bases {
+		p, err := b128.DecodePoint(base)
+		if err != nil {
+			return fmt.Errorf("base %d: %w", i, err)
+		}
+		points[i] = p
+	}
+	this.lock.Lock()
+	defer this.lock.Unlock()
+	k, err := RandomScalarFrom(this.random)
+	if err != nil {
+		return err
+	}
+	var session = &peerSession{k: k, bases: args.Bases, points: make([]types.Point, len(points)), purpose: args.Purpose}
+	for i, p := range points {
+		session.points[i] = b128.Serialize(p.Mul(k))
+	}
+	this.next++
+	delete(this.sessions, this.next-maxKeySessions)
+	this.sessions[this.next] = session
+	*reply = ThresholdCommitment{Session: this.next, Digest: pointsDigest(session.points)}
+	return nil
+}
+
+// Reveal takes the points of party 1 and answers the committed points.
+func (this *ThresholdPeer) Reveal(args ThresholdReveal, reply *[]types.Point) error {
+	this.lock.Lock()
+	defer this.lock.Unlock()
+	session, ok := this.sessions[args.Session]
+	if !ok || session.revealed {
+		return ErrUnknownSession
+	}
+	if len(args.Points) != len(session.points) {
+		return fmt.Errorf("%w: %d points, want %d", ErrThresholdProtocol, len(args.Points), len(session.points))
+	}
+	session.revealed, session.theirs = true, args.Points
+	*reply = session.points
+	return nil
+}
+
+// Respond answers k2 + c*x2 once per revealed session, once its purpose is
+// approved.
+func (this *ThresholdPeer) Respond(args KeyChallenge, reply *big.Int) error {
+	share, err := this.account()
+	if err != nil {
+		return err
+	}
+	if args.C == nil || args.C.Sign() < 0 || args.C.Cmp(b128.Q().Int) >= 0 {
+		return fmt.Errorf("%w: challenge", ErrInvalidScalar)
+	}
+	this.lock.Lock()
+	session, ok := this.sessions[args.Session]
+	if ok && session.revealed {
+		delete(this.sessions, args.Session)
+	}
+	this.lock.Unlock()
+	if !ok || !session.revealed {
+		return ErrUnknownSession
+	}
+	if err := session.bound(share.Y, args.C); err != nil {
+		return err
+	}
+	if err := this.check(session.purpose); err != nil {
+		return err
+	}
+	s := session.k.RedAdd(ebigint.ToNBigInt(args.C).ToRed(b128.Q()).RedMul(share.Share.X))
+	reply.Set(s.Int)
+	return nil
+}
+
+const thresholdServiceName = "HCashThreshold"
+
+// ServeThreshold answers the connections of l with peer until l is closed.
+func ServeThreshold(l net.Listener, peer *ThresholdPeer) error {
+	server := rpc.NewServer()
+	if err := server.RegisterName(thresholdServiceName, peer); err != nil {
+		return err
+	}
+	server.Accept(l)
+	return nil
+}
+
+// DialThreshold connects party 1 to party 2 served by ServeThreshold.
+func DialThreshold(network, address string) (ThresholdTransport, error) {
+	return rpc.Dial(network, address)
+}
+
+// NewLoopbackTransport serves peer in process over a pipe, with the same
+// encoding as a socket. It is meant for tests.
+func NewLoopbackTransport(peer *ThresholdPeer) (ThresholdTransport, error) {
+	server := rpc.NewServer()
+	if err := server.RegisterName(thresholdServiceName, peer); err != nil {
+		return nil, err
+	}
+	client, conn := net.Pipe()
+	go server.ServeConn(conn)
+	return rpc.NewClient(client), nil
+}
+
+func thresholdCall(peer ThresholdTransport, method string, args interface{}, reply interface{}) error {
+	if err := peer.Call(thresholdServiceName+"."+method, args, reply); err != nil {
+		return fmt.Errorf("%w: %s: %s", ErrThresholdProtocol, method, err.Error())
+	}
+	return nil
+}
+
+// RunThresholdDKG generates a new 2-of-2 account with party 2 and returns
+// the share of party 1.
+func RunThresholdDKG(peer ThresholdTransport, random io.Reader) (ThresholdShare, error) {
+	if random == nil {
+		random = rand.Reader
+	}
+	share, err := CreateAccountWithReader(random)
+	if err != nil {
+		return ThresholdShare{}, err
+	}
+	proof, err := newThresholdProof(share)
+	if err != nil {
+		return ThresholdShare{}, err
+	}
+
+	var peerProof ThresholdProof
+	if err := thresholdCall(peer, "DKGCommit", proof.digest(), &peerProof); err != nil {
+		return ThresholdShare{}, err
+	}
+	if err := peerProof.verify(); err != nil {
+		return ThresholdShare{}, fmt.Errorf("%w: share of party 2: %s", ErrThresholdProtocol, err.Error())
+	}
+	y, err := addPoints(share.Y, peerProof.Y)
+	if err != nil {
+		return ThresholdShare{}, err
+	}
+	var peerY types.Point
+	if err := thresholdCall(peer, "DKGReveal", proof, &peerY); err != nil {
+		return ThresholdShare{}, err
+	}
+	if peerY != y {
+		return ThresholdShare{}, fmt.Errorf("%w: joint y differs", ErrThresholdProtocol)
+	}
+	return ThresholdShare{Share: share, Y: y}, nil
+}
+
+type thresholdSession struct {
+	k    *ebigint.NBigInt
+	peer uint64
+}
+
+// ThresholdKey is the KeyHandle of party 1.
+type ThresholdKey struct {
+	share  ThresholdShare
+	peer   ThresholdTransport
+	random io.Reader
+
+	lock     sync.Mutex
+	next     uint64
+	sessions map[uint64]thresholdSession
+}
+
+func NewThresholdKey(share ThresholdShare, peer ThresholdTransport) (*ThresholdKey, error) {
+	if _, err := signKey(share.Share); err != nil {
+		return nil, err
+	}
+	return &ThresholdKey{share: share, peer: peer, sessions: make(map[uint64]thresholdSession)}, nil
+}
+
+// SetRandom replaces the source of the nonces of party 1, nil is
+// crypto/rand.
+func (this *ThresholdKey) SetRandom(r io.Reader) {
+	this.random = r
+}
+
+func (this *ThresholdKey) PublicKey() (types.Point, error) {
+	return this.share.Y, nil
+}
+
+func (this *ThresholdKey) U(epoch int) (types.Point, error) {
+	var u2 types.Point
+	if err := thresholdCall(this.peer, "U", epoch, &u2); err != nil {
+		return types.Point{}, err
+	}
+	return addPoints(b128.Serialize(U(epoch, this.share.Share.X)), u2)
+}
+
+// For returns the key with the purpose given to party 2 for its Commit and
+// Decrypt, the purpose of a proof is ThresholdProve with the statement.
+// Without it the purpose is ThresholdDecrypt or ThresholdProve with no data.
+func (this *ThresholdKey) For(kind string, data []byte) KeyHandle {
+	return thresholdPurposeKey{this, ThresholdPurpose{Kind: kind, Data: data}}
+}
+
+type thresholdPurposeKey struct {
+	*ThresholdKey
+	purpose ThresholdPurpose
+}
+
+func (this thresholdPurposeKey) Decrypt(CL, CR types.Point) (types.Point, error) {
+	return this.decrypt(CL, CR, this.purpose)
+}
+
+func (this thresholdPurposeKey) Commit(bases []types.Point) (*KeyCommitment, error) {
+	return this.commit(bases, this.purpose)
+}
+
+func (this *ThresholdKey) Decrypt(CL, CR types.Point) (types.Point, error) {
+	return this.decrypt(CL, CR, ThresholdPurpose{Kind: ThresholdDecrypt, Data: append(CL.Bytes(), CR.Bytes()...)})
+}
+
+func (this *ThresholdKey) decrypt(CL, CR types.Point, purpose ThresholdPurpose) (types.Point, error) {
+	var share2 types.Point
+	if err := thresholdCall(this.peer, "Decrypt", ThresholdDecryptArgs{CR: CR, Purpose: purpose}, &share2); err != nil {
+		return types.Point{}, err
+	}
+	nShare2, err := b128.DecodePoint(share2)
+	if err != nil {
+		return types.Point{}, err
+	}
+	// CL - CR^x1 - CR^x2
+	gB, err := NewMemoryKey(this.share.Share.X).Decrypt(CL, CR)
+	if err != nil {
+		return types.Point{}, err
+	}
+	return addPoints(gB, b128.Serialize(nShare2.Neg()))
+}
+
+func (this *ThresholdKey) Commit(bases []types.Point) (*KeyCommitment, error) {
+	return this.commit(bases, ThresholdPurpose{Kind: ThresholdProve})
+}
+
+func (this *ThresholdKey) commit(bases []types.Point, purpose ThresholdPurpose) (*KeyCommitment, error) {
+	var points = make([]Point, len(bases))
+	for i, base := range bases {
+		p, err := b128.DecodePoint(base)
+		if err != nil {
+			return nil, fmt.Errorf("base %d: %w", i, err)
+		}
+		points[i] = p
+	}
+	var committed ThresholdCommitment
+	if err := thresholdCall(this.peer, "Commit", ThresholdCommit{Bases: bases, Purpose: purpose}, &committed); err != nil {
+		return nil, err
+	}
+	random := newScalarReader(this.random)
+	k := random.next()
+	if random.err != nil {
+		return nil, random.err
+	}
+	var reveal = ThresholdReveal{Session: committed.Session, Points: make([]types.Point, len(points))}
+	for i, p := range points {
+		reveal.Points[i] = b128.Serialize(p.Mul(k))
+	}
+	var points2 []types.Point
+	if err := thresholdCall(this.peer, "Reveal", reveal, &points2); err != nil {
+		return nil, err
+	}
+	if len(points2) != len(points) || !bytes.Equal(pointsDigest(points2), committed.Digest) {
+		return nil, fmt.Errorf("%w: points of party 2 do not match the commitment", ErrThresholdProtocol)
+	}
+
+	var commitment = &KeyCommitment{Points: make([]types.Point, len(points))}
+	for i := range points {
+		sum, err := addPoints(reveal.Points[i], points2[i])
+		if err != nil {
+			return nil, err
+		}
+		commitment.Points[i] = sum
+	}
+	this.lock.Lock()
+	defer this.lock.Unlock()
+	this.next++
+	delete(this.sessions, this.next-maxKeySessions)
+	commitment.Session = this.next
+	this.sessions[this.next] = thresholdSession{k: k, peer: committed.Session}
+	return commitment, nil
+}
+
+func (this *ThresholdKey) Respond(session uint64, c *ebigint.NBigInt) (*ebigint.NBigInt, error) {
+	if c == nil || c.Sign() < 0 || c.Cmp(b128.Q().Int) >= 0 {
+		return nil, fmt.Errorf("%w: challenge", ErrInvalidScalar)
+	}
+	this.lock.Lock()
+	s, ok := this.sessions[session]
+	delete(this.sessions, session)
+	this.lock.Unlock()
+	if !ok {
+		return nil, ErrUnknownSession
+	}
+	var s2 = new(big.Int)
+	if err := thresholdCall(this.peer, "Respond", KeyChallenge{Session: s.peer, C: c.Int}, s2); err != nil {
+		return nil, err
+	}
+	s1 := s.k.RedAdd(c.ToRed(b128.Q()).RedMul(this.share.Share.X))
+	return s1.RedAdd(ebigint.ToNBigInt(s2).ToRed(b128.Q())), nil
+}
+
+// schnorr makes a joint signature (c, s) of purpose with the nonce
+// K = g^k1 + g^k2.
+func (this *ThresholdKey) schnorr(purpose ThresholdPurpose, challenge func(y, K Point) (*ebigint.NBigInt, error)) (*ebigint.NBigInt, *ebigint.NBigInt, error) {
+	y, err := b128.DecodePoint(this.share.Y)
+	if err != nil {
+		return nil, nil, err
+	}
+	session, K, err := keyCommit(thresholdPurposeKey{this, purpose}, FixedG().Point())
+	if err != nil {
+		return nil, nil, err
+	}
+	c, err := challenge(y, K[0])
+	if err != nil {
+		return nil, nil, err
+	}
+	s, err := this.Respond(session, c)
+	if err != nil {
+		return nil, nil, err
+	}
+	return c, s, nil
+}
+
+func (this *ThresholdKey) Sign(address []byte) (*ebigint.NBigInt, *ebigint.NBigInt, error) {
+	return this.schnorr(ThresholdPurpose{Kind: ThresholdSign, Data: address}, func(y, K Point) (*ebigint.NBigInt, error) {
+		return signChallenge(address, y, K)
+	})
+}
+
+func (this *ThresholdKey) SignMessage(message []byte) (*ebigint.NBigInt, *ebigint.NBigInt, error) {
+	return this.schnorr(ThresholdPurpose{Kind: ThresholdMessage, Data: message}, func(y, K Point) (*ebigint.NBigInt, error) {
+		return messageChallenge(y, K, message), nil
+	})
+}
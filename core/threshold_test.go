@@ -0,0 +1,179 @@
+package core
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/hpb-project/HCash-SDK/common"
+	"github.com/hpb-project/HCash-SDK/common/types"
+	"github.com/hpb-project/HCash-SDK/core/ebigint"
+	"gotest.tools/assert"
+)
+
+// splitThreshold gives x as x1 + x2 to a ThresholdKey and its peer.
+func splitThreshold(t *testing.T, x *ebigint.NBigInt) (*ThresholdKey, *ThresholdPeer) {
+	x = x.ToRed(b128.Q())
+	x1 := testScalar
+	x2 := x.RedSub(x1)
+	y := b128.Serialize(FixedG().Mul(x))
+
+	peer, err := NewThresholdPeerWithShare(ThresholdShare{Share: CreateAccountWithX(x2), Y: y}, nil)
+	assert.NilError(t, err)
+	transport, err := NewLoopbackTransport(peer)
+	assert.NilError(t, err)
+	key, err := NewThresholdKey(ThresholdShare{Share: CreateAccountWithX(x1), Y: y}, transport)
+	assert.NilError(t, err)
+	return key, peer
+}
+
+func TestThresholdDKG(t *testing.T) {
+	peer := NewThresholdPeer(nil)
+	transport, err := NewLoopbackTransport(peer)
+	assert.NilError(t, err)
+	share1, err := RunThresholdDKG(transport, nil)
+	assert.NilError(t, err)
+	share2, err := peer.Share()
+	assert.NilError(t, err)
+
+	// y = g^x1 + g^x2 and x1 + x2 is known to nobody but this test.
+	assert.Equal(t, share1.Y, share2.Y)
+	y, err := addPoints(share1.Share.Y, share2.Share.Y)
+	assert.NilError(t, err)
+	assert.Equal(t, y, share1.Y)
+	x := share1.Share.X.RedAdd(share2.Share.X)
+	assert.Equal(t, b128.Serialize(FixedG().Mul(x)), share1.Y)
+
+	key, err := NewThresholdKey(share1, transport)
+	assert.NilError(t, err)
+
+	address := common.FromHex("0xE4920905e06c6B6070477c40B85756ffDa3cD3E6")
+	c, s, err := key.Sign(address)
+	assert.NilError(t, err)
+	assert.NilError(t, VerifySign(address, share1.Y, c, s))
+
+	message := []byte("hcash")
+	c, s, err = key.SignMessage(message)
+	assert.NilError(t, err)
+	assert.NilError(t, VerifyMessage(share1.Y, message, c, s))
+
+	u, err := key.U(53672920)
+	assert.NilError(t, err)
+	assert.Equal(t, u, b128.Serialize(U(53672920, x)))
+
+	// CL = g^7 + y^r, CR = g^r.
+	r := testScalar
+	CL := b128.Serialize(FixedG().Mul(ebigint.NewNBigInt(7)).Add(FixedG().Mul(x).Mul(r)))
+	CR := b128.Serialize(FixedG().Mul(r))
+	balance, err := ReadBalanceWithKey(CL, CR, key)
+	assert.NilError(t, err)
+	assert.Equal(t, balance, uint32(7))
+
+	// the key generation runs once.
+	_, err = RunThresholdDKG(transport, nil)
+	assert.Assert(t, errors.Is(err, ErrThresholdProtocol))
+}
+
+func TestThresholdProve(t *testing.T) {
+	statement, witness := burnVector()
+	key, _ := splitThreshold(t, ebigint.FromHex(witness.SK))
+	witness.SK = ""
+	witness.Key = key
+	assert.NilError(t, CheckBurn(statement, witness))
+	proof, err := ProveBurn(statement, witness)
+	assert.NilError(t, err)
+	u, err := key.U(statement.Epoch)
+	assert.NilError(t, err)
+	assert.NilError(t, VerifyBurn(statement, u, proof))
+
+	istatement, iwitness := transferVector()
+	key, _ = splitThreshold(t, ebigint.FromHex(iwitness.SK))
+	iwitness.SK = ""
+	iwitness.Key = key
+	tproof, err := ProveTransfer(istatement, iwitness)
+	assert.NilError(t, err)
+	u, err = key.U(istatement.Epoch)
+	assert.NilError(t, err)
+	assert.NilError(t, VerifyTransfer(istatement, u, tproof))
+}
+
+func TestThresholdPeer(t *testing.T) {
+	peer := NewThresholdPeer(testRandom())
+	var proof ThresholdProof
+	// no share yet.
+	assert.Assert(t, errors.Is(peer.U(1, nil), ErrThresholdProtocol))
+
+	// a share that does not match the commitment.
+	assert.NilError(t, peer.DKGCommit([]byte("commitment"), &proof))
+	share, err := CreateAccountWithReader(testRandom())
+	assert.NilError(t, err)
+	mine, err := newThresholdProof(share)
+	assert.NilError(t, err)
+	err = peer.DKGReveal(mine, nil)
+	assert.Assert(t, errors.Is(err, ErrThresholdProtocol))
+
+	// a share without proof of possession.
+	mine.S = new(big.Int).Add(mine.S, big.NewInt(1))
+	assert.Assert(t, errors.Is(peer.DKGReveal(mine, nil), ErrInvalidSignature))
+
+	key, _ := splitThreshold(t, testScalar.RedAdd(testScalar))
+	peer2, err := NewThresholdPeerWithShare(ThresholdShare{Share: CreateAccountWithX(testScalar), Y: key.share.Y}, nil)
+	assert.NilError(t, err)
+	var committed ThresholdCommitment
+	assert.NilError(t, peer2.Commit(ThresholdCommit{}, &committed))
+	// no response before the reveal, one after it.
+	var s big.Int
+	challenge := KeyChallenge{Session: committed.Session, C: testScalar.Int}
+	assert.Assert(t, errors.Is(peer2.Respond(challenge, &s), ErrUnknownSession))
+	var points []types.Point
+	assert.NilError(t, peer2.Reveal(ThresholdReveal{Session: committed.Session}, &points))
+	assert.NilError(t, peer2.Respond(challenge, &s))
+	assert.Assert(t, errors.Is(peer2.Respond(challenge, &s), ErrUnknownSession))
+
+	// party 1 can not pile up sessions.
+	for i := 0; i < maxKeySessions+10; i++ {
+		assert.NilError(t, peer2.Commit(ThresholdCommit{}, &committed))
+	}
+	assert.Equal(t, len(peer2.sessions), maxKeySessions)
+}
+
+func TestThresholdApprove(t *testing.T) {
+	statement, witness := burnVector()
+	key, peer := splitThreshold(t, ebigint.FromHex(witness.SK))
+	data, err := EncodeBurnStatement(statement, EnvelopeOptions{})
+	assert.NilError(t, err)
+	var approved []string
+	peer.SetApprove(func(kind string, data []byte) error {
+		if kind == ThresholdMessage && string(data) == "refuse" || kind == ThresholdDecrypt {
+			return errors.New("refused")
+		}
+		approved = append(approved, kind)
+		return nil
+	})
+
+	message := []byte("hcash")
+	c, s, err := key.SignMessage(message)
+	assert.NilError(t, err)
+	assert.NilError(t, VerifyMessage(key.share.Y, message, c, s))
+	_, _, err = key.SignMessage([]byte("refuse"))
+	assert.ErrorContains(t, err, "refused")
+	_, err = key.Decrypt(statement.CLn, statement.CRn)
+	assert.ErrorContains(t, err, "refused")
+
+	// party 1 can not get another challenge answered under an approved
+	// message.
+	other := key.For(ThresholdMessage, message)
+	session, _, err := keyCommit(other, FixedG().Point())
+	assert.NilError(t, err)
+	_, err = other.Respond(session, testScalar)
+	assert.ErrorContains(t, err, "challenge is not the one of the message")
+
+	witness.SK = ""
+	witness.Key = key.For(ThresholdProve, data)
+	proof, err := ProveBurn(statement, witness)
+	assert.NilError(t, err)
+	u, err := key.U(statement.Epoch)
+	assert.NilError(t, err)
+	assert.NilError(t, VerifyBurn(statement, u, proof))
+	assert.DeepEqual(t, approved, []string{ThresholdMessage, ThresholdProve, ThresholdProve})
+}
@@ -4,12 +4,13 @@ import (
 	"encoding/hex"
 	"encoding/json"
 	"errors"
+	"fmt"
 	abi "github.com/ethereum/go-ethereum/accounts/abi"
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/hpb-project/HCash-SDK/common"
 	"github.com/hpb-project/HCash-SDK/common/types"
 	"github.com/hpb-project/HCash-SDK/core/ebigint"
-	solsha3 "github.com/miguelmota/go-solidity-sha3"
+	"io"
 	"log"
 	"math/big"
 )
@@ -60,21 +61,11 @@ func (a *Account) UnmarshalJSON(input []byte) error {
 	return nil
 }
 
-func ReadBalance(CL, CR types.Point, x *ebigint.NBigInt) int {
-	nCL := b128.UnSerialize(CL)
-	nCR := b128.UnSerialize(CR)
-
-	var gB = nCL.Add(nCR.Mul(x.RedNeg()))
-	var accumulator = b128.Zero()
-
-	for i := uint(0); i < b128.B_MAX(); i++ {
-		if accumulator.Equal(gB) {
-			return int(i)
-		}
-		accumulator = accumulator.Add(b128.CurveG())
-	}
-
-	return 0
+// ReadBalance decrypts the account (CL, CR) with x using the default
+// BalanceTable. ErrBalanceNotFound means the plaintext is not in [0, B_MAX],
+// usually because x does not belong to the account.
+func ReadBalance(CL, CR types.Point, x *ebigint.NBigInt) (uint32, error) {
+	return DefaultBalanceTable().ReadBalance(CL, CR, x)
 }
 
 func Hash(str string) *ebigint.NBigInt {
@@ -85,10 +76,9 @@ func Hash(str string) *ebigint.NBigInt {
 	return ebigint.FromBytes(hash).ToRed(b128.Q())
 }
 
-// just for test with special k.
-func SignWithRandom(address []byte, keypair Account, k *ebigint.NBigInt) (*ebigint.NBigInt, *ebigint.NBigInt, error) {
-	var K = b128.CurveG().Mul(k)
-
+// signChallenge is the Schnorr challenge of the register signature in
+// ZSC.sol, keccak256(abi.encode(address, y, K)) mod q.
+func signChallenge(address []byte, y Point, K Point) (*ebigint.NBigInt, error) {
 	addressT, _ := abi.NewType("address", "", nil)
 	bytes32_2T, _ := abi.NewType("bytes32[2]", "", nil)
 
@@ -106,97 +96,119 @@ func SignWithRandom(address []byte, keypair Account, k *ebigint.NBigInt) (*ebigi
 	var addr = ETH_ADDR{}
 	copy(addr[:], address[:])
 
-	var bx, by ABI_Bytes32
-	bbx := common.FromHex(keypair.Y.GX())
-	bby := common.FromHex(keypair.Y.GY())
-	copy(bx[:], bbx)
-	copy(by[:], bby)
-
-	skey := b128.Serialize(K)
-	var kx, ky ABI_Bytes32
-	bkx := common.FromHex(skey.GX())
-	bky := common.FromHex(skey.GY())
-	copy(kx[:], bkx[:])
-	copy(ky[:], bky[:])
 	thebytes, e := arguments.Pack(
 		addr,
-		[2]ABI_Bytes32{bx, by},
-		[2]ABI_Bytes32{kx, ky},
+		parsePoint2ABI_Bytes32_2(y),
+		parsePoint2ABI_Bytes32_2(K),
 	)
 	if e != nil {
-		log.Println("Sign pack failed, e:", e.Error())
-		return nil, nil, e
+		return nil, e
 	}
-	//log.Println("Sign abiencoder=", hex.EncodeToString(thebytes))
+	return Hash(hex.EncodeToString(thebytes)), nil
+}
 
-	c := Hash(hex.EncodeToString(thebytes))
-	var s = c.RedMul(keypair.X).RedAdd(k)
-	//log.Println("Sign c=", c.Text(16))
-	//log.Println("Sign s=", s.Text(16))
+// signNonce derives the nonce of a register signature from x, the ZSC
+// address and y, so that signing never depends on the quality of the RNG.
+// extra hedges it with fresh randomness, it may be nil.
+func signNonce(address []byte, x *ebigint.NBigInt, y Point, extra []byte) *ebigint.NBigInt {
+	var addr = ETH_ADDR{}
+	copy(addr[:], address[:])
+	yb := parsePoint2ABI_Bytes32_2(y)
+	h := crypto.Keccak256(addr[:], yb[0][:], yb[1][:])
 
-	return c, s, e
+	k := rfc6979Nonce(b128.Q().Int, x.Int, h, extra)
+	return ebigint.ToNBigInt(k).ForceRed(b128.Q())
 }
 
-func Sign(address []byte, keypair Account) (*ebigint.NBigInt, *ebigint.NBigInt, error) {
-	var k = b128.RandomScalar()
-	var K = b128.CurveG().Mul(k)
-
-	addressT, _ := abi.NewType("address", "", nil)
-	bytes32_2T, _ := abi.NewType("bytes32[2]", "", nil)
+func signKey(keypair Account) (Point, error) {
+	y, err := b128.DecodePoint(keypair.Y)
+	if err != nil {
+		return Point{}, fmt.Errorf("y: %w", err)
+	}
+	if !FixedG().Mul(keypair.X).Equal(y) {
+		return Point{}, ErrKeyMismatch
+	}
+	return y, nil
+}
 
-	arguments := abi.Arguments{
-		{
-			Type: addressT,
-		},
-		{
-			Type: bytes32_2T,
-		},
-		{
-			Type: bytes32_2T,
-		},
+// SignWithRandom signs with the nonce k, a k must never be used twice.
+// Sign derives k deterministically and should be preferred.
+func SignWithRandom(address []byte, keypair Account, k *ebigint.NBigInt) (*ebigint.NBigInt, *ebigint.NBigInt, error) {
+	y, err := signKey(keypair)
+	if err != nil {
+		return nil, nil, err
 	}
-	var addr = ETH_ADDR{}
-	copy(addr[:], address[:])
+	c, err := signChallenge(address, y, FixedG().Mul(k))
+	if err != nil {
+		log.Println("Sign pack failed, e:", err.Error())
+		return nil, nil, err
+	}
+	var s = c.RedMul(keypair.X).RedAdd(k)
+	return c, s, nil
+}
 
-	var bx, by ABI_Bytes32
-	bbx := common.FromHex(keypair.Y.GX())
-	bby := common.FromHex(keypair.Y.GY())
-	copy(bx[:], bbx)
-	copy(by[:], bby)
-
-	skey := b128.Serialize(K)
-	var kx, ky ABI_Bytes32
-	bkx := common.FromHex(skey.GX())
-	bky := common.FromHex(skey.GY())
-	copy(kx[:], bkx[:])
-	copy(ky[:], bky[:])
-	thebytes, e := arguments.Pack(
-		addr,
-		[2]ABI_Bytes32{bx, by},
-		[2]ABI_Bytes32{kx, ky},
-	)
-	if e != nil {
-		log.Println("Sign pack failed, e:", e.Error())
-		return nil, nil, e
+// Sign makes the Schnorr signature (c, s) that ZSC.sol register expects,
+// with the nonce derived from x, address and y as in RFC 6979.
+func Sign(address []byte, keypair Account) (*ebigint.NBigInt, *ebigint.NBigInt, error) {
+	y, err := signKey(keypair)
+	if err != nil {
+		return nil, nil, err
 	}
-	//log.Println("Sign abiencoder=", hex.EncodeToString(thebytes))
+	return SignWithRandom(address, keypair, signNonce(address, keypair.X, y, nil))
+}
 
-	c := Hash(hex.EncodeToString(thebytes))
-	var s = c.RedMul(keypair.X).RedAdd(k)
-	//log.Println("Sign c=", c.Text(16))
-	//log.Println("Sign s=", s.Text(16))
+// SignWithReader is Sign with 32 bytes from random mixed into the nonce
+// derivation, a broken random still gives a safe nonce.
+func SignWithReader(address []byte, keypair Account, random io.Reader) (*ebigint.NBigInt, *ebigint.NBigInt, error) {
+	y, err := signKey(keypair)
+	if err != nil {
+		return nil, nil, err
+	}
+	var extra = make([]byte, 32)
+	if _, err := io.ReadFull(random, extra); err != nil {
+		return nil, nil, err
+	}
+	return SignWithRandom(address, keypair, signNonce(address, keypair.X, y, extra))
+}
 
-	return c, s, nil
+// VerifySign checks a register signature the same way ZSC.sol does:
+// K = g^s * y^-c and c == keccak256(abi.encode(address, y, K)) mod q.
+func VerifySign(address []byte, y types.Point, c, s *ebigint.NBigInt) error {
+	ny, err := b128.DecodePoint(y)
+	if err != nil {
+		return fmt.Errorf("y: %w", err)
+	}
+	if c.Sign() < 0 || c.Cmp(b128.Q().Int) >= 0 {
+		return ErrInvalidSignature
+	}
+	var K = FixedG().Mul(s.ToRed(b128.Q())).Add(ny.Mul(c.ToRed(b128.Q()).RedNeg()))
+	challenge, err := signChallenge(address, ny, K)
+	if err != nil {
+		return err
+	}
+	if !challenge.Eq(c) {
+		return ErrInvalidSignature
+	}
+	return nil
 }
 
 func CreateAccount() Account {
 	x := b128.RandomScalar()
-	p := b128.CurveG().Mul(x)
+	p := FixedG().Mul(x)
 	return Account{X: x, Y: b128.Serialize(p)}
 }
 
+// CreateAccountWithReader is CreateAccount with the secret x read from random.
+func CreateAccountWithReader(random io.Reader) (Account, error) {
+	x, err := RandomScalarFrom(random)
+	if err != nil {
+		return Account{}, err
+	}
+	return CreateAccountWithX(x), nil
+}
+
 func CreateAccountWithX(x *ebigint.NBigInt) Account {
-	p := b128.CurveG().Mul(x)
+	p := FixedG().Mul(x)
 	return Account{X: x, Y: b128.Serialize(p)}
 }
 
@@ -233,12 +245,9 @@ func MapInto(seed string) Point {
 	}
 }
 
+// GEpoch returns MapInto("Zether", epoch), recent epochs are cached.
 func GEpoch(epoch int) Point {
-	// soliditySha3
-	hash := solsha3.SoliditySHA3(solsha3.String("Zether"), solsha3.Uint256(big.NewInt(int64(epoch))))
-	hashstr := "0x" + hex.EncodeToString(hash)
-
-	return MapInto(hashstr)
+	return gEpochs.get(epoch)
 }
 
 func U(epoch int, x *ebigint.NBigInt) Point {
@@ -2,6 +2,7 @@ package core
 
 import (
 	"encoding/hex"
+	"errors"
 	"github.com/hpb-project/HCash-SDK/common"
 	"github.com/hpb-project/HCash-SDK/common/types"
 	"github.com/hpb-project/HCash-SDK/core/ebigint"
@@ -66,7 +67,7 @@ func BenchmarkCreateAccount(b *testing.B) {
 	b.ResetTimer()
 	for i := 0; i < b.N; i++ {
 		account := CreateAccount()
-		account.String()
+		_ = account.String()
 	}
 }
 
@@ -87,10 +88,64 @@ func TestSign(t *testing.T) {
 	assert.Equal(t, PaddingString(s.Text(16), 64), "003fe7000561eeebccd4bff3160cd7f8fd50db62904d8fa217692a1f6ca8e7ed")
 }
 
+func TestSignWithReader(t *testing.T) {
+	address := common.FromHex("E4920905e06c6B6070477c40B85756ffDa3cD3E6")
+	account, err := CreateAccountWithReader(NewDRBG([]byte("account")))
+	assert.NilError(t, err)
+	again, _ := CreateAccountWithReader(NewDRBG([]byte("account")))
+	assert.Assert(t, account.X.Eq(again.X))
+	assert.Assert(t, FixedG().Mul(account.X).Equal(b128.UnSerialize(account.Y)))
+
+	c, s, err := SignWithReader(address, account, NewDRBG([]byte("nonce")))
+	assert.NilError(t, err)
+	assert.NilError(t, VerifySign(address, account.Y, c, s))
+	c2, s2, _ := SignWithReader(address, account, NewDRBG([]byte("nonce")))
+	assert.Assert(t, c.Eq(c2) && s.Eq(s2))
+
+	// the hedged nonce differs from the deterministic one.
+	c3, _, _ := Sign(address, account)
+	assert.Assert(t, !c.Eq(c3))
+
+	_, _, err = SignWithReader(address, account, failingReader{})
+	assert.ErrorContains(t, err, "no entropy")
+}
+
+func TestVerifySign(t *testing.T) {
+	address := common.FromHex("E4920905e06c6B6070477c40B85756ffDa3cD3E6")
+	nx, _ := new(big.Int).SetString("299569ae0ae1d40140fd8d9afc54d2f581a292fd13fe88c7033d488119bb95b7", 16)
+	account := CreateAccountWithX(ebigint.ToNBigInt(nx).ToRed(b128.Q()))
+
+	c, s, err := Sign(address, account)
+	assert.NilError(t, err)
+	assert.NilError(t, VerifySign(address, account.Y, c, s))
+
+	// the nonce only depends on x, the address and y.
+	c2, s2, _ := Sign(address, account)
+	assert.Assert(t, c.Eq(c2) && s.Eq(s2))
+	other := common.FromHex("d80ac1fb177c0b8d9c66de2b9657dd57084a2d7f")
+	c3, _, _ := Sign(other, account)
+	assert.Assert(t, !c.Eq(c3))
+
+	// the fixed vector of TestSign verifies as well.
+	vc := ebigint.FromHex("206db78bfe338ecffd5b2f0606789ff1045bfbf1e46c897f8fa2e2115e19ed74")
+	vs := ebigint.FromHex("003fe7000561eeebccd4bff3160cd7f8fd50db62904d8fa217692a1f6ca8e7ed")
+	assert.NilError(t, VerifySign(address, account.Y, vc, vs))
+
+	assert.Assert(t, errors.Is(VerifySign(other, account.Y, c, s), ErrInvalidSignature))
+	assert.Assert(t, errors.Is(VerifySign(address, account.Y, c, s.RedAdd(ebigint.NewNBigInt(1).ToRed(b128.Q()))), ErrInvalidSignature))
+	assert.Assert(t, errors.Is(VerifySign(address, account.Y, ebigint.ToNBigInt(new(big.Int).Add(c.Int, b128.Q().Int)), s), ErrInvalidSignature))
+
+	wrong := account
+	wrong.X = wrong.X.RedAdd(ebigint.NewNBigInt(1).ToRed(b128.Q()))
+	_, _, err = Sign(address, wrong)
+	assert.Assert(t, errors.Is(err, ErrKeyMismatch))
+}
+
 func TestReadBalance(t *testing.T) {
-	var CL = types.Point{"0x1b5d4b9abe488e61bbb92edff41682560a9d6e02335e2bca9b50881c9540e393", "0x15dc61a9eff5d5a4e70ed97cbce60f7afc69c9925a409ddba365897f1384ca58"}
-	var CR = types.Point{"0x0456301d6013d1cc52455a37c8762f2463b1c7e148d55e1c7d9980d8ed8d54b8", "0x27e78199776a73737fa833429fd64e00fa592ca21dda2e92d3489c96148308cb"}
+	var CL = types.MustPoint("0x1b5d4b9abe488e61bbb92edff41682560a9d6e02335e2bca9b50881c9540e393", "0x15dc61a9eff5d5a4e70ed97cbce60f7afc69c9925a409ddba365897f1384ca58")
+	var CR = types.MustPoint("0x0456301d6013d1cc52455a37c8762f2463b1c7e148d55e1c7d9980d8ed8d54b8", "0x27e78199776a73737fa833429fd64e00fa592ca21dda2e92d3489c96148308cb")
 	nx, _ := new(big.Int).SetString("20a89bb465e9e2262e25901525509686f6a26b2fba976f1d9ff00a0cdbb362b0", 16)
-	balance := ReadBalance(CL, CR, ebigint.ToNBigInt(nx).ForceRed(b128.Q()))
-	assert.Equal(t, balance, 2)
+	balance, err := ReadBalance(CL, CR, ebigint.ToNBigInt(nx).ForceRed(b128.Q()))
+	assert.NilError(t, err)
+	assert.Equal(t, balance, uint32(2))
 }
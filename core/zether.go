@@ -1,11 +1,14 @@
 package core
 
 import (
+	"crypto/rand"
 	"encoding/hex"
 	"errors"
+	"fmt"
 	"github.com/ethereum/go-ethereum/accounts/abi"
 	"github.com/hpb-project/HCash-SDK/common"
 	"github.com/hpb-project/HCash-SDK/core/ebigint"
+	"io"
 	"log"
 	"math"
 	"math/big"
@@ -50,37 +53,47 @@ var (
 )
 
 func (z ZetherProof) Serialize() string {
+	return z.serialize(b128.Representation)
+}
+
+// SerializeCompressed is Serialize with every point in the 33 bytes
+// compressed form, use DecompressTransferProof before sending it on chain.
+func (z ZetherProof) SerializeCompressed() string {
+	return z.serialize(b128.CompressedRepresentation)
+}
+
+func (z ZetherProof) serialize(point func(Point) string) string {
 	result := "0x"
-	result += b128.Representation(z.BA)[2:]
-	result += b128.Representation(z.BS)[2:]
-	result += b128.Representation(z.A)[2:]
-	result += b128.Representation(z.B)[2:]
+	result += point(z.BA)[2:]
+	result += point(z.BS)[2:]
+	result += point(z.A)[2:]
+	result += point(z.B)[2:]
 
 	for _, CLnG_k := range z.CLnG {
-		result += b128.Representation(CLnG_k)[2:]
+		result += point(CLnG_k)[2:]
 	}
 
 	for _, CRnG_k := range z.CRnG {
-		result += b128.Representation(CRnG_k)[2:]
+		result += point(CRnG_k)[2:]
 	}
 
 	for _, C_0G_k := range z.C_0G {
-		result += b128.Representation(C_0G_k)[2:]
+		result += point(C_0G_k)[2:]
 	}
 	for _, DG_k := range z.DG {
-		result += b128.Representation(DG_k)[2:]
+		result += point(DG_k)[2:]
 	}
 	for _, y_0G_k := range z.y_0G {
-		result += b128.Representation(y_0G_k)[2:]
+		result += point(y_0G_k)[2:]
 	}
 	for _, gG_k := range z.gG {
-		result += b128.Representation(gG_k)[2:]
+		result += point(gG_k)[2:]
 	}
 	for _, C_XG_k := range z.C_XG {
-		result += b128.Representation(C_XG_k)[2:]
+		result += point(C_XG_k)[2:]
 	}
 	for _, y_XG_k := range z.y_XG {
-		result += b128.Representation(y_XG_k)[2:]
+		result += point(y_XG_k)[2:]
 	}
 
 	fv := z.f.GetVector()
@@ -91,7 +104,7 @@ func (z ZetherProof) Serialize() string {
 
 	tcv := z.tCommits.GetVector()
 	for _, commit := range tcv {
-		result += b128.Representation(commit)[2:]
+		result += point(commit)[2:]
 	}
 
 	result += b128.Bytes(z.tHat.Int)[2:]
@@ -102,24 +115,138 @@ func (z ZetherProof) Serialize() string {
 	result += b128.Bytes(z.s_b.Int)[2:]
 	result += b128.Bytes(z.s_tau.Int)[2:]
 
-	result += z.ipProof.Serialize()[2:]
+	result += z.ipProof.serialize(point)[2:]
 
 	return result
 }
 
+// UnserializeZetherProof is the inverse of Serialize. N is the anonymity
+// set size the proof was generated for, it must be a power of two.
+func UnserializeZetherProof(proof string, N int) (*ZetherProof, error) {
+	reader, err := newProofReader(proof)
+	if err != nil {
+		return nil, err
+	}
+	return unserializeZetherProof(reader, N)
+}
+
+// UnserializeZetherProofCompressed is the inverse of SerializeCompressed.
+func UnserializeZetherProofCompressed(proof string, N int) (*ZetherProof, error) {
+	reader, err := newCompressedProofReader(proof)
+	if err != nil {
+		return nil, err
+	}
+	return unserializeZetherProof(reader, N)
+}
+
+func unserializeZetherProof(reader *proofReader, N int) (*ZetherProof, error) {
+	if N < 2 || N&(N-1) != 0 {
+		return nil, fmt.Errorf("%w: %d", ErrRingSizeNotPowerOfTwo, N)
+	}
+	var m = 0
+	for 1<<uint(m) < N {
+		m++
+	}
+	if want := reader.size(18+8*m, 10+2*m); reader.Len() != want {
+		return nil, fmt.Errorf("invalid transfer proof length %d, want %d for ring size %d",
+			reader.Len(), want, N)
+	}
+
+	var err error
+	z := &ZetherProof{}
+	for _, p := range []*Point{&z.BA, &z.BS, &z.A, &z.B} {
+		if *p, err = reader.readPoint(); err != nil {
+			return nil, err
+		}
+	}
+	for _, v := range []*[]Point{&z.CLnG, &z.CRnG, &z.C_0G, &z.DG, &z.y_0G, &z.gG, &z.C_XG, &z.y_XG} {
+		if *v, err = reader.readPoints(m); err != nil {
+			return nil, err
+		}
+	}
+
+	f, err := reader.readScalars(2 * m)
+	if err != nil {
+		return nil, err
+	}
+	z.f = NewFieldVector(f)
+	if z.z_A, err = reader.readScalar(); err != nil {
+		return nil, err
+	}
+
+	tCommits, err := reader.readPoints(2)
+	if err != nil {
+		return nil, err
+	}
+	z.tCommits = NewGeneratorVector(tCommits)
+	for _, e := range []**ebigint.NBigInt{&z.tHat, &z.mu, &z.c, &z.s_sk, &z.s_r, &z.s_b, &z.s_tau} {
+		if *e, err = reader.readScalar(); err != nil {
+			return nil, err
+		}
+	}
+
+	if z.ipProof, err = unserializeInnerProductProof(reader, 6); err != nil {
+		return nil, err
+	}
+	if err = reader.finish(); err != nil {
+		return nil, err
+	}
+	return z, nil
+}
+
+func (z *ZetherProof) GetBA() Point                   { return z.BA }
+func (z *ZetherProof) GetBS() Point                   { return z.BS }
+func (z *ZetherProof) GetA() Point                    { return z.A }
+func (z *ZetherProof) GetB() Point                    { return z.B }
+func (z *ZetherProof) GetCLnG() []Point               { return z.CLnG }
+func (z *ZetherProof) GetCRnG() []Point               { return z.CRnG }
+func (z *ZetherProof) GetC_0G() []Point               { return z.C_0G }
+func (z *ZetherProof) GetDG() []Point                 { return z.DG }
+func (z *ZetherProof) GetY_0G() []Point               { return z.y_0G }
+func (z *ZetherProof) GetGG() []Point                 { return z.gG }
+func (z *ZetherProof) GetC_XG() []Point               { return z.C_XG }
+func (z *ZetherProof) GetY_XG() []Point               { return z.y_XG }
+func (z *ZetherProof) GetF() *FieldVector             { return z.f }
+func (z *ZetherProof) GetZ_A() *ebigint.NBigInt       { return z.z_A }
+func (z *ZetherProof) GetTCommits() *GeneratorVector  { return z.tCommits }
+func (z *ZetherProof) GetTHat() *ebigint.NBigInt      { return z.tHat }
+func (z *ZetherProof) GetMu() *ebigint.NBigInt        { return z.mu }
+func (z *ZetherProof) GetC() *ebigint.NBigInt         { return z.c }
+func (z *ZetherProof) GetS_sk() *ebigint.NBigInt      { return z.s_sk }
+func (z *ZetherProof) GetS_r() *ebigint.NBigInt       { return z.s_r }
+func (z *ZetherProof) GetS_b() *ebigint.NBigInt       { return z.s_b }
+func (z *ZetherProof) GetS_tau() *ebigint.NBigInt     { return z.s_tau }
+func (z *ZetherProof) GetIPProof() *InnerProductProof { return z.ipProof }
+
 type ZetherProver struct {
 	params   *GeneratorParams
 	ipProver *InnerProductProver
+	workers  int
+	random   io.Reader
 }
 
 func NewZetherProver() ZetherProver {
-	params := NewGeneratorParams(int(64), nil, nil)
+	params := GetGeneratorParams(64)
 	return ZetherProver{
 		params:   params,
 		ipProver: new(InnerProductProver),
+		workers:  1,
+		random:   rand.Reader,
 	}
 }
 
+// SetRandom sets the source of the blinding scalars, crypto/rand by default.
+// A seeded DRBG makes the proof reproducible.
+func (this *ZetherProver) SetRandom(r io.Reader) {
+	this.random = r
+}
+
+// SetWorkers sets how many goroutines compute the independent commitments
+// of a proof. The proof does not depend on it.
+func (this *ZetherProver) SetWorkers(n int) {
+	this.workers = n
+}
+
 type PList struct {
 	data [][]*ebigint.NBigInt
 }
@@ -186,74 +313,58 @@ type interTransferWitness struct {
 	bTransfer *ebigint.NBigInt
 	bDiff     *ebigint.NBigInt
 	index     []int
-	sk        *ebigint.NBigInt
+	key       KeyHandle
 	r         *ebigint.NBigInt
 }
 
 func (this ZetherProver) toInnerStatement(tstatement TransferStatement) (*interTransferStatement, error) {
+	if err := checkRing(tstatement); err != nil {
+		return nil, err
+	}
 	statement := &interTransferStatement{}
 	statement.Epoch = tstatement.Epoch
 
-	{
-		gv := make([]Point, 0)
-		for _, CLn := range tstatement.CLn {
-			p := b128.UnSerialize(CLn)
-			gv = append(gv, p)
-		}
-		statement.CLn = NewGeneratorVector(gv)
+	var err error
+	if statement.CLn, err = decodePoints("CLn", tstatement.CLn); err != nil {
+		return nil, err
 	}
-	{
-		gv := make([]Point, 0)
-		for _, CRn := range tstatement.CRn {
-			p := b128.UnSerialize(CRn)
-			gv = append(gv, p)
-		}
-		statement.CRn = NewGeneratorVector(gv)
+	if statement.CRn, err = decodePoints("CRn", tstatement.CRn); err != nil {
+		return nil, err
 	}
-	{
-		gv := make([]Point, 0)
-		for _, C := range tstatement.C {
-			p := b128.UnSerialize(C)
-			gv = append(gv, p)
-		}
-		statement.C = NewGeneratorVector(gv)
+	if statement.C, err = decodePoints("C", tstatement.C); err != nil {
+		return nil, err
 	}
-	{
-		statement.D = b128.UnSerialize(tstatement.D)
+	if statement.D, err = b128.DecodePoint(tstatement.D); err != nil {
+		return nil, fmt.Errorf("D: %w", err)
 	}
-	{
-		gv := make([]Point, 0)
-		for _, y := range tstatement.Y {
-			p := b128.UnSerialize(y)
-			gv = append(gv, p)
-		}
-		statement.Y = NewGeneratorVector(gv)
+	if statement.Y, err = decodePoints("y", tstatement.Y); err != nil {
+		return nil, err
 	}
-
 	return statement, nil
 }
 
 func (this ZetherProver) toWitness(iwitness TransferWitness) (*interTransferWitness, error) {
+	if err := checkValue("bTransfer", iwitness.BTransfer); err != nil {
+		return nil, err
+	}
+	if iwitness.BDiff < 0 {
+		return nil, fmt.Errorf("%w: bDiff %d", ErrInsufficientBalance, iwitness.BDiff)
+	}
+	if err := checkValue("bDiff", iwitness.BDiff); err != nil {
+		return nil, err
+	}
 	witness := &interTransferWitness{}
 	witness.bTransfer = ebigint.NewNBigInt(int64(iwitness.BTransfer)).ToRed(b128.Q())
 	witness.bDiff = ebigint.NewNBigInt(int64(iwitness.BDiff)).ToRed(b128.Q())
 	witness.index = make([]int, len(iwitness.Index))
-	for i := 0; i < len(iwitness.Index); i++ {
-		witness.index[i] = iwitness.Index[i]
-	}
+	copy(witness.index, iwitness.Index)
 
-	sk, ok := new(big.Int).SetString(common.HexWithout0x(iwitness.SK), 16)
-	if !ok {
-		return nil, errors.New("witness sk is invalid")
-	} else {
-		witness.sk = ebigint.ToNBigInt(sk).ForceRed(b128.Q())
+	var err error
+	if witness.key, err = witnessKey(iwitness.Key, iwitness.SK, this.random); err != nil {
+		return nil, err
 	}
-
-	random, ok := new(big.Int).SetString(common.HexWithout0x(iwitness.R), 16)
-	if !ok {
-		return nil, errors.New("witness random is invalid")
-	} else {
-		witness.r = ebigint.ToNBigInt(random).ForceRed(b128.Q())
+	if witness.r, err = decodeScalar("r", iwitness.R); err != nil {
+		return nil, err
 	}
 	return witness, nil
 }
@@ -332,27 +443,33 @@ func statementHash(istatement TransferStatement) *ebigint.NBigInt {
 	return statementHash
 }
 
-func (this ZetherProver) GenerateProof(istatement TransferStatement, iwitness TransferWitness) *ZetherProof {
+// GenerateProof proves the transfer, the statement and witness are checked
+// with the same rules as CheckTransfer first.
+func (this ZetherProver) GenerateProof(istatement TransferStatement, iwitness TransferWitness) (*ZetherProof, error) {
 	var err error
 	proof := &ZetherProof{}
 
-	shash := statementHash(istatement)
-
 	var statement *interTransferStatement
 	var witness *interTransferWitness
 
 	statement, err = this.toInnerStatement(istatement)
 	if err != nil {
-		log.Printf("to inner statement failed, err:%s\n", err.Error())
-		return nil
+		return nil, err
 	}
-
 	witness, err = this.toWitness(iwitness)
 	if err != nil {
-		log.Printf("to inner witness failed, err:%s\n", err.Error())
-		return nil
+		return nil, err
+	}
+	if err = checkTransfer(statement, witness); err != nil {
+		return nil, err
 	}
 
+	shash := statementHash(istatement)
+	if shash == nil {
+		return nil, errors.New("statement hash failed")
+	}
+	random := newScalarReader(this.random)
+
 	var aL *FieldVector
 	{
 		t1 := new(big.Int).Lsh(witness.bDiff.Int, 32)
@@ -370,31 +487,31 @@ func (this ZetherProver) GenerateProof(istatement TransferStatement, iwitness Tr
 	// luxq continue
 
 	var aR = aL.Plus(ebigint.NewNBigInt(1).ToRed(b128.Q()).RedNeg())
-	var alpha = b128.RandomScalar()
+	var alpha = random.next()
 	proof.BA = this.params.Commit(alpha, aL, aR)
 
 	var vsL = make([]*ebigint.NBigInt, 64)
 	var vsR = make([]*ebigint.NBigInt, 64)
 	for i := 0; i < 64; i++ {
-		vsL[i] = b128.RandomScalar()
-		vsR[i] = b128.RandomScalar()
+		vsL[i] = random.next()
+		vsR[i] = random.next()
 	}
 	var sL = NewFieldVector(vsL)
 	var sR = NewFieldVector(vsR)
-	var rho = b128.RandomScalar()
+	var rho = random.next()
 	proof.BS = this.params.Commit(rho, sL, sR)
 
 	var N = statement.Y.Length()
 
 	var m = big.NewInt(int64(N)).BitLen() - 1
-	var r_A = b128.RandomScalar()
-	var r_B = b128.RandomScalar()
+	var r_A = random.next()
+	var r_B = random.next()
 
 	var a *FieldVector
 	{
 		var pa = make([]*ebigint.NBigInt, 2*m)
 		for i := 0; i < 2*m; i++ {
-			pa[i] = b128.RandomScalar()
+			pa[i] = random.next()
 		}
 		a = NewFieldVector(pa)
 	}
@@ -496,18 +613,17 @@ func (this ZetherProver) GenerateProof(istatement TransferStatement, iwitness Tr
 			vB,
 		)
 		if perr != nil {
-			log.Println("packed failed, err:", perr.Error())
-			return nil
+			return nil, perr
 		}
 		v = Hash(hex.EncodeToString(bytes))
 		//log.Println("v hash = ", v.Text(16))
 	}
 	var phi, chi, psi, omega = make([]*ebigint.NBigInt, m), make([]*ebigint.NBigInt, m), make([]*ebigint.NBigInt, m), make([]*ebigint.NBigInt, m)
 	for i := 0; i < m; i++ {
-		phi[i] = b128.RandomScalar()
-		chi[i] = b128.RandomScalar()
-		psi[i] = b128.RandomScalar()
-		omega[i] = b128.RandomScalar()
+		phi[i] = random.next()
+		chi[i] = random.next()
+		psi[i] = random.next()
+		omega[i] = random.next()
 	}
 	//log.Println("m = ", m)
 	NP, NQ := make([]*FieldVector, m), make([]*FieldVector, m)
@@ -531,70 +647,72 @@ func (this ZetherProver) GenerateProof(istatement TransferStatement, iwitness Tr
 	}
 
 	{
-		//proof.CLnG = Array.from({ length: m }).map((_, k) => statement['CLn'].commit(P[k]).add(statement['y'].getVector()[witness['index'][0]].mul(phi[k])));
 		proof.CLnG = make([]Point, m)
-		for k := 0; k < m; k++ {
-			proof.CLnG[k] = statement.CLn.Commit(NP[k]).Add(statement.Y.GetVector()[witness.index[0]].Mul(phi[k]))
-		}
-
-		//proof.CRnG = Array.from({ length: m }).map((_, k) => statement['CRn'].commit(P[k]).add(params.getG().mul(phi[k])));
 		proof.CRnG = make([]Point, m)
-		for k := 0; k < m; k++ {
-			proof.CRnG[k] = statement.CRn.Commit(NP[k]).Add(this.params.GetG().Mul(phi[k]))
-		}
-
-		//proof.C_0G = Array.from({ length: m }).map((_, k) => statement['C'].commit(P[k]).add(statement['y'].getVector()[witness['index'][0]].mul(chi[k])));
 		proof.C_0G = make([]Point, m)
-		for k := 0; k < m; k++ {
-			proof.C_0G[k] = statement.C.Commit(NP[k]).Add(statement.Y.GetVector()[witness.index[0]].Mul(chi[k]))
-		}
-
-		//proof.DG = Array.from({ length: m }).map((_, k) => params.getG().mul(chi[k]));
 		proof.DG = make([]Point, m)
-		for k := 0; k < m; k++ {
-			proof.DG[k] = this.params.GetG().Mul(chi[k])
-		}
-
-		//proof.y_0G = Array.from({ length: m }).map((_, k) => statement['y'].commit(P[k]).add(statement['y'].getVector()[witness['index'][0]].mul(psi[k])));
 		proof.y_0G = make([]Point, m)
-		for k := 0; k < m; k++ {
-			proof.y_0G[k] = statement.Y.Commit(NP[k]).Add(statement.Y.GetVector()[witness.index[0]].Mul(psi[k]))
-		}
-
-		//proof.gG = Array.from({ length: m }).map((_, k) => params.getG().mul(psi[k]));
 		proof.gG = make([]Point, m)
-		for k := 0; k < m; k++ {
-			proof.gG[k] = this.params.GetG().Mul(psi[k])
-		}
-
-		//proof.C_XG = Array.from({ length: m }).map((_, k) => statement['D'].mul(omega[k]));
 		proof.C_XG = make([]Point, m)
-		for k := 0; k < m; k++ {
-			proof.C_XG[k] = statement.D.Mul(omega[k])
-		}
-
-		//proof.y_XG = Array.from({ length: m }).map((_, k) => params.getG().mul(omega[k]));
 		proof.y_XG = make([]Point, m)
-		for k := 0; k < m; k++ {
-			proof.y_XG[k] = this.params.GetG().Mul(omega[k])
-		}
+
+		var y0 = statement.Y.GetVector()[witness.index[0]]
+		parallelFor(this.workers, 8*m, func(j int) {
+			var k = j % m
+			switch j / m {
+			case 0:
+				//proof.CLnG = Array.from({ length: m }).map((_, k) => statement['CLn'].commit(P[k]).add(statement['y'].getVector()[witness['index'][0]].mul(phi[k])));
+				proof.CLnG[k] = statement.CLn.Commit(NP[k]).Add(y0.Mul(phi[k]))
+			case 1:
+				//proof.CRnG = Array.from({ length: m }).map((_, k) => statement['CRn'].commit(P[k]).add(params.getG().mul(phi[k])));
+				proof.CRnG[k] = statement.CRn.Commit(NP[k]).Add(this.params.GetFixedG().Mul(phi[k]))
+			case 2:
+				//proof.C_0G = Array.from({ length: m }).map((_, k) => statement['C'].commit(P[k]).add(statement['y'].getVector()[witness['index'][0]].mul(chi[k])));
+				proof.C_0G[k] = statement.C.Commit(NP[k]).Add(y0.Mul(chi[k]))
+			case 3:
+				//proof.DG = Array.from({ length: m }).map((_, k) => params.getG().mul(chi[k]));
+				proof.DG[k] = this.params.GetFixedG().Mul(chi[k])
+			case 4:
+				//proof.y_0G = Array.from({ length: m }).map((_, k) => statement['y'].commit(P[k]).add(statement['y'].getVector()[witness['index'][0]].mul(psi[k])));
+				proof.y_0G[k] = statement.Y.Commit(NP[k]).Add(y0.Mul(psi[k]))
+			case 5:
+				//proof.gG = Array.from({ length: m }).map((_, k) => params.getG().mul(psi[k]));
+				proof.gG[k] = this.params.GetFixedG().Mul(psi[k])
+			case 6:
+				//proof.C_XG = Array.from({ length: m }).map((_, k) => statement['D'].mul(omega[k]));
+				proof.C_XG[k] = statement.D.Mul(omega[k])
+			case 7:
+				//proof.y_XG = Array.from({ length: m }).map((_, k) => params.getG().mul(omega[k]));
+				proof.y_XG[k] = this.params.GetFixedG().Mul(omega[k])
+			}
+		})
 	}
+	// every party i adds temp_i * (poly[k][index0 - i] - poly[k][index1 - i])
+	// to C_XG[k], the terms are computed per party and summed in order.
+	var vPows = make([]*ebigint.NBigInt, N)
 	var vPow = ebigint.NewNBigInt(1).ToRed(b128.Q())
 	for i := 0; i < N; i++ {
-		var temp = this.params.GetG().Mul(witness.bTransfer.RedMul(vPow))
+		vPows[i] = vPow
+		if i != 0 {
+			vPow = vPow.RedMul(v)
+		}
+	}
+	var terms = make([][]Point, N)
+	parallelFor(this.workers, N, func(i int) {
+		var temp = this.params.GetFixedG().Mul(witness.bTransfer.RedMul(vPows[i]))
 		var poly = NQ
 		if i%2 == 0 {
 			poly = NP
 		}
 		//proof.C_XG = proof.C_XG.map((C_XG_k, k) => C_XG_k.add(temp.mul(poly[k].getVector()[(witness['index'][0] + N - (i - i % 2)) % N].redNeg().redAdd(poly[k].getVector()[(witness['index'][1] + N - (i - i % 2)) % N]))));
-		n_C_XG := make([]Point, len(proof.C_XG))
-		for k, C_XG_k := range proof.C_XG {
-			n_C_XG[k] = C_XG_k.Add(temp.Mul(poly[k].GetVector()[(witness.index[0]+N-(i-i%2))%N].RedNeg().RedAdd(poly[k].GetVector()[(witness.index[1]+N-(i-i%2))%N])))
+		terms[i] = make([]Point, m)
+		for k := 0; k < m; k++ {
+			terms[i][k] = temp.Mul(poly[k].GetVector()[(witness.index[0]+N-(i-i%2))%N].RedNeg().RedAdd(poly[k].GetVector()[(witness.index[1]+N-(i-i%2))%N]))
 		}
-
-		proof.C_XG = n_C_XG
-		if i != 0 {
-			vPow = vPow.RedMul(v)
+	})
+	for i := 0; i < N; i++ {
+		for k := 0; k < m; k++ {
+			proof.C_XG[k] = proof.C_XG[k].Add(terms[i][k])
 		}
 	}
 	//log.Println("vPow = ", vPow.Text(16))
@@ -705,7 +823,10 @@ func (this ZetherProver) GenerateProof(istatement TransferStatement, iwitness Tr
 	var lPoly = NewFieldVectorPolynomial(aL.Plus(z.RedNeg()), sL)
 	var rPoly = NewFieldVectorPolynomial(ys.Hadamard(aR.Plus(z)).Add(twoTimesZs), sR.Hadamard(ys))
 	var tPolyCoefficients = lPoly.InnerProduct(rPoly)
-	var polyCommitment = NewPolyCommitment(*this.params, tPolyCoefficients)
+	polyCommitment, err := NewPolyCommitment(*this.params, tPolyCoefficients, random.r)
+	if err != nil {
+		return nil, err
+	}
 
 	proof.tCommits = NewGeneratorVector(polyCommitment.GetCommitments())
 
@@ -756,10 +877,10 @@ func (this ZetherProver) GenerateProof(istatement TransferStatement, iwitness Tr
 	var wPow = ebigint.NewNBigInt(1).ToRed(b128.Q())
 	{
 		for k := 0; k < m; k++ {
-			CRnR = CRnR.Add(this.params.GetG().Mul(phi[k].RedNeg().RedMul(wPow)))
-			DR = DR.Add(this.params.GetG().Mul(chi[k].RedNeg().RedMul(wPow)))
+			CRnR = CRnR.Add(this.params.GetFixedG().Mul(phi[k].RedNeg().RedMul(wPow)))
+			DR = DR.Add(this.params.GetFixedG().Mul(chi[k].RedNeg().RedMul(wPow)))
 			y_0R = y_0R.Add(statement.Y.GetVector()[witness.index[0]].Mul(psi[k].RedNeg().RedMul(wPow)))
-			gR = gR.Add(this.params.GetG().Mul(psi[k].RedNeg().RedMul(wPow)))
+			gR = gR.Add(this.params.GetFixedG().Mul(psi[k].RedNeg().RedMul(wPow)))
 			y_XR = y_XR.Add(proof.y_XG[k].Mul(ebigint.ToNBigInt(big.NewInt(0).Neg(wPow.Int)).ToRed(wPow.GetRed())))
 
 			p = p.Add(NP[k].Times(wPow))
@@ -770,7 +891,7 @@ func (this ZetherProver) GenerateProof(istatement TransferStatement, iwitness Tr
 		CRnR = CRnR.Add(statement.CRn.GetVector()[witness.index[0]].Mul(wPow))
 		y_0R = y_0R.Add(statement.Y.GetVector()[witness.index[0]].Mul(wPow))
 		DR = DR.Add(statement.D.Mul(wPow))
-		gR = gR.Add(this.params.GetG().Mul(wPow))
+		gR = gR.Add(this.params.GetFixedG().Mul(wPow))
 		{
 			//p = p.add(new FieldVector(Array.from({ length: N }).map((_, i) => i == witness['index'][0] ? wPow : new BN().toRed(bn128.q))));
 			vtp := make([]*ebigint.NBigInt, N)
@@ -797,9 +918,17 @@ func (this ZetherProver) GenerateProof(istatement TransferStatement, iwitness Tr
 		}
 	}
 	{
-		var convolver = NewConvolver()
-		var y_p = convolver.Convolution_Point(p, statement.Y)
-		var y_q = convolver.Convolution_Point(q, statement.Y)
+		var y_pq = make([]*GeneratorVector, 2)
+		var errs = make([]error, 2)
+		parallelFor(this.workers, 2, func(i int) {
+			y_pq[i], errs[i] = NewConvolver().Convolution_Point([]*FieldVector{p, q}[i], statement.Y)
+		})
+		for _, e := range errs {
+			if e != nil {
+				return nil, e
+			}
+		}
+		var y_p, y_q = y_pq[0], y_pq[1]
 		vPow = ebigint.NewNBigInt(1).ToRed(b128.Q())
 		for i := 0; i < N; i++ {
 			var y_poly *GeneratorVector
@@ -815,17 +944,25 @@ func (this ZetherProver) GenerateProof(istatement TransferStatement, iwitness Tr
 			}
 		}
 	}
-	var k_sk = b128.RandomScalar()
-	var k_r = b128.RandomScalar()
-	var k_b = b128.RandomScalar()
-	var k_tau = b128.RandomScalar()
+	// k_sk is drawn by the key, A_y, A_u and the sk part of A_b are its
+	// commitments.
+	session, A_sk, err := keyCommit(witness.key, gR, DR.Mul(zs[0].RedNeg()).Add(CRnR.Mul(zs[1])), GEpoch(statement.Epoch))
+	if err != nil {
+		return nil, err
+	}
+	var k_r = random.next()
+	var k_b = random.next()
+	var k_tau = random.next()
+	if random.err != nil {
+		return nil, random.err
+	}
 
-	var A_y = gR.Mul(k_sk)
-	var A_D = this.params.GetG().Mul(k_r)
-	var A_b = this.params.GetG().Mul(k_b).Add(DR.Mul(zs[0].RedNeg()).Add(CRnR.Mul(zs[1])).Mul(k_sk))
+	var A_y = A_sk[0]
+	var A_D = this.params.GetFixedG().Mul(k_r)
+	var A_b = this.params.GetFixedG().Mul(k_b).Add(A_sk[1])
 	var A_X = y_XR.Mul(k_r)
-	var A_t = this.params.GetG().Mul(k_b.RedNeg()).Add(this.params.GetH().Mul(k_tau))
-	var A_u = GEpoch(statement.Epoch).Mul(k_sk)
+	var A_t = this.params.GetFixedG().Mul(k_b.RedNeg()).Add(this.params.GetFixedH().Mul(k_tau))
+	var A_u = A_sk[2]
 
 	{
 		arguments := abi.Arguments{
@@ -863,7 +1000,9 @@ func (this ZetherProver) GenerateProof(istatement TransferStatement, iwitness Tr
 		proof.c = Hash(hex.EncodeToString(bytes))
 	}
 
-	proof.s_sk = k_sk.RedAdd(proof.c.RedMul(witness.sk))
+	if proof.s_sk, err = witness.key.Respond(session, proof.c); err != nil {
+		return nil, err
+	}
 	proof.s_r = k_r.RedAdd(proof.c.RedMul(witness.r))
 
 	proof.s_b = k_b.RedAdd(proof.c.RedMul(witness.bTransfer.RedMul(zs[0]).RedAdd(witness.bDiff.RedMul(zs[1])).RedMul(wPow)))
@@ -874,7 +1013,7 @@ func (this ZetherProver) GenerateProof(istatement TransferStatement, iwitness Tr
 	var hExp = ys.Times(z).Add(twoTimesZs)
 	{
 		var P = proof.BA.Add(proof.BS.Mul(x)).Add(gs.Sum().Mul(z.RedNeg())).Add(hPrimes.Commit(hExp))
-		P = P.Add(this.params.GetH().Mul(proof.mu.RedNeg()))
+		P = P.Add(this.params.GetFixedH().Mul(proof.mu.RedNeg()))
 
 		arguments := abi.Arguments{
 			{
@@ -886,7 +1025,7 @@ func (this ZetherProver) GenerateProof(istatement TransferStatement, iwitness Tr
 		)
 		o := Hash(hex.EncodeToString(bytes))
 
-		var u_x = this.params.GetG().Mul(o)
+		var u_x = this.params.GetFixedG().Mul(o)
 		P = P.Add(u_x.Mul(proof.tHat))
 
 		var primeBase = NewGeneratorParams(u_x, gs, hPrimes)
@@ -900,7 +1039,7 @@ func (this ZetherProver) GenerateProof(istatement TransferStatement, iwitness Tr
 
 		proof.ipProof = this.ipProver.GenerateProof(ipStatement, ipWitness, o)
 	}
-	return proof
+	return proof, nil
 }
 
 func PaddingString(in string, padding int) string {
@@ -0,0 +1,338 @@
+package core
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/hpb-project/HCash-SDK/common/types"
+	"github.com/hpb-project/HCash-SDK/core/ebigint"
+)
+
+// ZetherVerifier is a port of ZetherVerifier.sol, it checks a transfer proof
+// without sending it to the chain.
+type ZetherVerifier struct {
+	params     *GeneratorParams
+	ipVerifier *InnerProductVerifier
+}
+
+func NewZetherVerifier() ZetherVerifier {
+	params := GetGeneratorParams(64)
+	return ZetherVerifier{
+		params:     params,
+		ipVerifier: new(InnerProductVerifier),
+	}
+}
+
+// recursivePolynomials evaluates the products of f[baseline:current] for
+// every index, as recursivePolynomials in ZetherVerifier.sol.
+func (this ZetherVerifier) recursivePolynomials(baseline, current int, accum *ebigint.NBigInt, f [][2]*ebigint.NBigInt) []*ebigint.NBigInt {
+	var size = 1 << uint(current-baseline)
+	var result = make([]*ebigint.NBigInt, size)
+	if current == baseline {
+		result[0] = accum
+		return result
+	}
+	current = current - 1
+
+	var left = this.recursivePolynomials(baseline, current, accum.RedMul(f[current][0]), f)
+	var right = this.recursivePolynomials(baseline, current, accum.RedMul(f[current][1]), f)
+	for i := 0; i < size/2; i++ {
+		result[i] = left[i]
+		result[i+size/2] = right[i]
+	}
+	return result
+}
+
+func (this ZetherVerifier) assemblePolynomials(f [][2]*ebigint.NBigInt) [2]*FieldVector {
+	var m = len(f) / 2
+	var result [2]*FieldVector
+	for i := 0; i < 2; i++ {
+		one := ebigint.NewNBigInt(1).ToRed(b128.Q())
+		result[i] = NewFieldVector(this.recursivePolynomials(i*m, (i+1)*m, one, f))
+	}
+	return result
+}
+
+func (this ZetherVerifier) assembleConvolutions(exponent [2]*FieldVector, base *GeneratorVector) ([2]*GeneratorVector, error) {
+	var convolver = NewConvolver()
+	var result [2]*GeneratorVector
+	for i := 0; i < 2; i++ {
+		var err error
+		if result[i], err = convolver.Convolution_Point(exponent[i], base); err != nil {
+			return result, err
+		}
+	}
+	return result, nil
+}
+
+func (this ZetherVerifier) Verify(istatement TransferStatement, iu types.Point, proofHex string) error {
+	proof, err := UnserializeZetherProof(proofHex, len(istatement.Y))
+	if err != nil {
+		return err
+	}
+	// the sigma protocol takes 4m+2 generators, ZetherVerifier.sol reverts
+	// on larger rings.
+	if m := len(proof.CLnG); 4*m+2 > this.params.GetGS().Length() {
+		return fmt.Errorf("%w: %d", ErrRingSizeTooLarge, len(istatement.Y))
+	}
+	statement, err := ZetherProver{}.toInnerStatement(istatement)
+	if err != nil {
+		return err
+	}
+	var u = b128.UnSerialize(iu)
+
+	var m = len(proof.CLnG)
+	var N = 1 << uint(m)
+	if statement.Y.Length() != N || statement.CLn.Length() != N ||
+		statement.CRn.Length() != N || statement.C.Length() != N {
+		return errors.New("statement size does not match the proof")
+	}
+
+	var shash = statementHash(istatement)
+	if shash == nil {
+		return errors.New("statement hash failed")
+	}
+
+	var v, w *ebigint.NBigInt
+	{
+		arguments := abi.Arguments{
+			{Type: bytes32_T},
+			{Type: bytes32_2T},
+			{Type: bytes32_2T},
+			{Type: bytes32_2T},
+			{Type: bytes32_2T},
+		}
+		bytes, err := arguments.Pack(
+			parseBigInt2ABI_Bytes32(shash),
+			parsePoint2ABI_Bytes32_2(proof.BA),
+			parsePoint2ABI_Bytes32_2(proof.BS),
+			parsePoint2ABI_Bytes32_2(proof.A),
+			parsePoint2ABI_Bytes32_2(proof.B),
+		)
+		if err != nil {
+			return err
+		}
+		v = Hash(hex.EncodeToString(bytes))
+	}
+	{
+		arguments := abi.Arguments{
+			{Type: bytes32_T},
+			{Type: bytes32_2ST},
+			{Type: bytes32_2ST},
+			{Type: bytes32_2ST},
+			{Type: bytes32_2ST},
+			{Type: bytes32_2ST},
+			{Type: bytes32_2ST},
+			{Type: bytes32_2ST},
+			{Type: bytes32_2ST},
+		}
+		bytes, err := arguments.Pack(
+			parseBigInt2ABI_Bytes32(v),
+			parsePoints2ABI_Bytes32_2S(proof.CLnG),
+			parsePoints2ABI_Bytes32_2S(proof.CRnG),
+			parsePoints2ABI_Bytes32_2S(proof.C_0G),
+			parsePoints2ABI_Bytes32_2S(proof.DG),
+			parsePoints2ABI_Bytes32_2S(proof.y_0G),
+			parsePoints2ABI_Bytes32_2S(proof.gG),
+			parsePoints2ABI_Bytes32_2S(proof.C_XG),
+			parsePoints2ABI_Bytes32_2S(proof.y_XG),
+		)
+		if err != nil {
+			return err
+		}
+		w = Hash(hex.EncodeToString(bytes))
+	}
+
+	var f = make([][2]*ebigint.NBigInt, 2*m)
+	for k, f_k := range proof.f.GetVector() {
+		f[k][1] = f_k
+		f[k][0] = w.RedSub(f_k)
+	}
+
+	var gs = this.params.GetGS().GetVector()
+	var temp = b128.Zero()
+	for k := 0; k < 2*m; k++ {
+		temp = temp.Add(gs[k].Mul(f[k][1]))
+		temp = temp.Add(gs[k+2*m].Mul(f[k][1].RedMul(w.RedSub(f[k][1]))))
+	}
+	temp = temp.Add(gs[4*m].Mul(f[0][1].RedMul(f[m][1])).Add(gs[1+4*m].Mul(f[0][0].RedMul(f[m][0]))))
+	if !proof.B.Mul(w).Add(proof.A).Equal(temp.Add(this.params.GetFixedH().Mul(proof.z_A))) {
+		return errors.New("recovery failure for B^w * A")
+	}
+
+	var r = this.assemblePolynomials(f)
+	CR, err := this.assembleConvolutions(r, statement.C)
+	if err != nil {
+		return err
+	}
+	yR, err := this.assembleConvolutions(r, statement.Y)
+	if err != nil {
+		return err
+	}
+	var CR_0 = CR[0].GetVector()[0]
+	var yR_0 = yR[0].GetVector()[0]
+
+	var CLnR = statement.CLn.Commit(r[0])
+	var CRnR = statement.CRn.Commit(r[0])
+	var C_XR = b128.Zero()
+	var y_XR = b128.Zero()
+	var vPow = ebigint.NewNBigInt(1).ToRed(b128.Q())
+	for i := 0; i < N; i++ {
+		C_XR = C_XR.Add(CR[i%2].GetVector()[i/2].Mul(vPow))
+		y_XR = y_XR.Add(yR[i%2].GetVector()[i/2].Mul(vPow))
+		if i > 0 {
+			vPow = vPow.RedMul(v)
+		}
+	}
+
+	var DR = b128.Zero()
+	var gR = b128.Zero()
+	var wPow = ebigint.NewNBigInt(1).ToRed(b128.Q())
+	for k := 0; k < m; k++ {
+		var wNeg = wPow.RedNeg()
+		CLnR = CLnR.Add(proof.CLnG[k].Mul(wNeg))
+		CRnR = CRnR.Add(proof.CRnG[k].Mul(wNeg))
+		CR_0 = CR_0.Add(proof.C_0G[k].Mul(wNeg))
+		DR = DR.Add(proof.DG[k].Mul(wNeg))
+		yR_0 = yR_0.Add(proof.y_0G[k].Mul(wNeg))
+		gR = gR.Add(proof.gG[k].Mul(wNeg))
+		C_XR = C_XR.Add(proof.C_XG[k].Mul(wNeg))
+		y_XR = y_XR.Add(proof.y_XG[k].Mul(wNeg))
+
+		wPow = wPow.RedMul(w)
+	}
+	DR = DR.Add(statement.D.Mul(wPow))
+	gR = gR.Add(this.params.GetFixedG().Mul(wPow))
+
+	var y, z, zSum, k, t, x *ebigint.NBigInt
+	var ys = make([]*ebigint.NBigInt, 64)
+	{
+		arguments := abi.Arguments{
+			{Type: bytes32_T},
+		}
+		bytes, err := arguments.Pack(parseBigInt2ABI_Bytes32(w))
+		if err != nil {
+			return err
+		}
+		y = Hash(hex.EncodeToString(bytes))
+	}
+	ys[0] = ebigint.NewNBigInt(1).ToRed(b128.Q())
+	k = ebigint.NewNBigInt(1).ToRed(b128.Q())
+	for i := 1; i < 64; i++ {
+		ys[i] = ys[i-1].RedMul(y)
+		k = k.RedAdd(ys[i])
+	}
+	z = Hash(b128.Bytes(y.Int))
+	var zs = []*ebigint.NBigInt{z.RedExp(big.NewInt(2)), z.RedExp(big.NewInt(3))}
+	zSum = zs[0].RedAdd(zs[1]).RedMul(z)
+	{
+		two32 := ebigint.NewNBigInt(1 << 32).ToRed(b128.Q())
+		k = k.RedMul(z.RedSub(zs[0])).RedSub(zSum.RedMul(two32).RedSub(zSum))
+	}
+	t = proof.tHat.RedSub(k)
+
+	var twoTimesZSquared = make([]*ebigint.NBigInt, 64)
+	for i := 0; i < 32; i++ {
+		twoPow := ebigint.NewNBigInt(1 << uint(i)).ToRed(b128.Q())
+		twoTimesZSquared[i] = zs[0].RedMul(twoPow)
+		twoTimesZSquared[i+32] = zs[1].RedMul(twoPow)
+	}
+
+	var tCommits = proof.tCommits.GetVector()
+	{
+		arguments := abi.Arguments{
+			{Type: bytes32_T},
+			{Type: bytes32_2T},
+			{Type: bytes32_2T},
+		}
+		bytes, err := arguments.Pack(
+			parseBigInt2ABI_Bytes32(z),
+			parsePoint2ABI_Bytes32_2(tCommits[0]),
+			parsePoint2ABI_Bytes32_2(tCommits[1]),
+		)
+		if err != nil {
+			return err
+		}
+		x = Hash(hex.EncodeToString(bytes))
+	}
+	var tEval = tCommits[0].Mul(x).Add(tCommits[1].Mul(x.RedMul(x)))
+
+	var cNeg = proof.c.RedNeg()
+	var g = this.params.GetG()
+	var A_y = gR.Mul(proof.s_sk).Add(yR_0.Mul(cNeg))
+	var A_D = g.Mul(proof.s_r).Add(statement.D.Mul(cNeg))
+	var A_b = g.Mul(proof.s_b).Add(DR.Mul(zs[0].RedNeg()).Add(CRnR.Mul(zs[1])).Mul(proof.s_sk).Add(CR_0.Mul(zs[0].RedNeg()).Add(CLnR.Mul(zs[1])).Mul(cNeg)))
+	var A_X = y_XR.Mul(proof.s_r).Add(C_XR.Mul(cNeg))
+	var A_t = g.Mul(t).Add(tEval.Neg()).Mul(proof.c.RedMul(wPow)).Add(this.params.GetFixedH().Mul(proof.s_tau)).Add(g.Mul(proof.s_b.RedNeg()))
+	var A_u = GEpoch(statement.Epoch).Mul(proof.s_sk).Add(u.Mul(cNeg))
+
+	var c *ebigint.NBigInt
+	{
+		arguments := abi.Arguments{
+			{Type: bytes32_T},
+			{Type: bytes32_2T},
+			{Type: bytes32_2T},
+			{Type: bytes32_2T},
+			{Type: bytes32_2T},
+			{Type: bytes32_2T},
+			{Type: bytes32_2T},
+		}
+		bytes, err := arguments.Pack(
+			parseBigInt2ABI_Bytes32(x),
+			parsePoint2ABI_Bytes32_2(A_y),
+			parsePoint2ABI_Bytes32_2(A_D),
+			parsePoint2ABI_Bytes32_2(A_b),
+			parsePoint2ABI_Bytes32_2(A_X),
+			parsePoint2ABI_Bytes32_2(A_t),
+			parsePoint2ABI_Bytes32_2(A_u),
+		)
+		if err != nil {
+			return err
+		}
+		c = Hash(hex.EncodeToString(bytes))
+	}
+	if !c.Eq(proof.c) {
+		return errors.New("sigma protocol challenge equality failure")
+	}
+
+	return verifyRangeProof(this.params, this.ipVerifier, proof.c, proof.BA, proof.BS, x, z, ys, twoTimesZSquared, proof.mu, proof.tHat, proof.ipProof)
+}
+
+// verifyRangeProof checks the bulletproof part shared by the transfer and
+// burn verifiers.
+func verifyRangeProof(params *GeneratorParams, ipVerifier *InnerProductVerifier, c *ebigint.NBigInt,
+	BA, BS Point, x, z *ebigint.NBigInt, ys, twoTimesZSquared []*ebigint.NBigInt,
+	mu, tHat *ebigint.NBigInt, ipProof *InnerProductProof) error {
+	var o *ebigint.NBigInt
+	{
+		arguments := abi.Arguments{
+			{Type: bytes32_T},
+		}
+		bytes, err := arguments.Pack(parseBigInt2ABI_Bytes32(c))
+		if err != nil {
+			return err
+		}
+		o = Hash(hex.EncodeToString(bytes))
+	}
+
+	var n = len(ys)
+	var u_x = params.GetFixedG().Mul(o)
+	var hs = params.GetHS().GetVector()
+	var hPrimes = make([]Point, n)
+	var hPrimeSum = b128.Zero()
+	for i := 0; i < n; i++ {
+		hPrimes[i] = hs[i].Mul(ys[i].RedInvm())
+		hPrimeSum = hPrimeSum.Add(hPrimes[i].Mul(ys[i].RedMul(z).RedAdd(twoTimesZSquared[i])))
+	}
+	var P = BA.Add(BS.Mul(x)).Add(params.GetGS().Sum().Mul(z.RedNeg())).Add(hPrimeSum)
+	P = P.Add(params.GetFixedH().Mul(mu.RedNeg()))
+	P = P.Add(u_x.Mul(tHat))
+
+	var ipStatement = InnerProduct_statement{}
+	ipStatement.PrimeBase = NewGeneratorParams(u_x, params.GetGS(), NewGeneratorVector(hPrimes))
+	ipStatement.P = P
+	return ipVerifier.Verify(ipStatement, ipProof, o)
+}
@@ -126,18 +126,91 @@ func ParseSimulateAccounts(data string) (*ParseSimulateAccountsResponse, error)
 	start := hexdata
 	for len(start) > 0 {
 		item := [2]types.Point{}
-		account0_x := "0x" + hex.EncodeToString(start[:32])
-		account0_y := "0x" + hex.EncodeToString(start[32:64])
-		item[0].Set([]string{account0_x, account0_y})
-		//fmt.Printf("a0_x = %s, a0_y = %s, item = %s\n", account0_x, account0_y, item[0])
-
-		account1_x := "0x" + hex.EncodeToString(start[64:96])
-		account1_y := "0x" + hex.EncodeToString(start[96:128])
-		item[1].Set([]string{account1_x, account1_y})
-		//fmt.Printf("a1_x = %s, a1_y = %s, item = %s\n", account1_x, account1_y, item[1])
+		var err error
+		for i := range item {
+			if item[i], err = types.PointFromBytes(start[64*i : 64*i+64]); err != nil {
+				return nil, err
+			}
+		}
 		res.Accounts = append(res.Accounts, item)
 		start = start[128:]
 	}
 	return res, nil
 
 }
+
+// TransferSelector is the selector of ZSC.transfer.
+const TransferSelector = "eff4d178"
+
+// TransferCalldata is the decoded input of ZSC.transfer.
+type TransferCalldata struct {
+	C     []types.Point `json:"C"`
+	D     types.Point   `json:"D"`
+	Y     []types.Point `json:"y"`
+	U     types.Point   `json:"u"`
+	Proof string        `json:"proof"`
+}
+
+// ParseTransfer decodes the input of a ZSC.transfer transaction, with or
+// without the selector, the layout of Transfer.
+func ParseTransfer(data string) (*TransferCalldata, error) {
+	input := common.FromHex(data)
+	if len(input)%32 == 4 {
+		if hex.EncodeToString(input[:4]) != TransferSelector {
+			return nil, fmt.Errorf("invalid transfer selector %x", input[:4])
+		}
+		input = input[4:]
+	}
+	if len(input) < 7*32 {
+		return nil, errors.New("invalid transfer calldata length")
+	}
+	var res TransferCalldata
+	var err error
+	if res.D, err = types.PointFromBytes(input[32:96]); err != nil {
+		return nil, fmt.Errorf("D: %w", err)
+	}
+	if res.U, err = types.PointFromBytes(input[128:192]); err != nil {
+		return nil, fmt.Errorf("u: %w", err)
+	}
+	if res.C, err = abiPoints(input, 0); err != nil {
+		return nil, fmt.Errorf("C: %w", err)
+	}
+	if res.Y, err = abiPoints(input, 96); err != nil {
+		return nil, fmt.Errorf("y: %w", err)
+	}
+	proof, err := abiBytes(input, 192, 1)
+	if err != nil {
+		return nil, fmt.Errorf("proof: %w", err)
+	}
+	res.Proof = "0x" + hex.EncodeToString(proof)
+	return &res, nil
+}
+
+// abiBytes reads the dynamic array whose offset is at head, of items of
+// size bytes each.
+func abiBytes(input []byte, head int, size int) ([]byte, error) {
+	offset := new(big.Int).SetBytes(input[head : head+32])
+	if !offset.IsUint64() || offset.Uint64() > uint64(len(input)-32) {
+		return nil, errors.New("invalid offset")
+	}
+	start := int(offset.Uint64())
+	length := new(big.Int).SetBytes(input[start : start+32])
+	if !length.IsUint64() || length.Uint64() > uint64(len(input)-start-32)/uint64(size) {
+		return nil, errors.New("invalid length")
+	}
+	return input[start+32 : start+32+int(length.Uint64())*size], nil
+}
+
+func abiPoints(input []byte, head int) ([]types.Point, error) {
+	data, err := abiBytes(input, head, 64)
+	if err != nil {
+		return nil, err
+	}
+	var points = make([]types.Point, len(data)/64)
+	for i := range points {
+		if points[i], err = types.PointFromBytes(data[64*i : 64*i+64]); err != nil {
+			return nil, fmt.Errorf("point %d: %w", i, err)
+		}
+	}
+	return points, nil
+}
@@ -14,6 +14,39 @@ func hCashCreateAccount(secret string) string {
 	return account
 }
 
+//export hCashCreateAccountFromEthKey
+func hCashCreateAccountFromEthKey(input string) string {
+	var data = make([]byte, len(input))
+	copy(data, []byte(input))
+
+	result := client.CreateAccountFromEthKey(string(data))
+	return result
+}
+
+//export hCashEthKeyMessage
+func hCashEthKeyMessage() string {
+	result := client.EthKeyMessage()
+	return result
+}
+
+//export hCashEncodeAddress
+func hCashEncodeAddress(input string) string {
+	var data = make([]byte, len(input))
+	copy(data, []byte(input))
+
+	result := client.EncodeAddress(string(data))
+	return result
+}
+
+//export hCashDecodeAddress
+func hCashDecodeAddress(input string) string {
+	var data = make([]byte, len(input))
+	copy(data, []byte(input))
+
+	result := client.DecodeAddress(string(data))
+	return result
+}
+
 //export hCashSign
 func hCashSign(input string) string {
 	var data = make([]byte, len(input))
@@ -23,6 +56,150 @@ func hCashSign(input string) string {
 	return signed
 }
 
+//export hCashVerifySign
+func hCashVerifySign(input string) string {
+	var data = make([]byte, len(input))
+	copy(data, []byte(input))
+
+	result := client.VerifySign(string(data))
+	return result
+}
+
+//export hCashSignMessage
+func hCashSignMessage(input string) string {
+	var data = make([]byte, len(input))
+	copy(data, []byte(input))
+
+	result := client.SignMessage(string(data))
+	return result
+}
+
+//export hCashVerifyMessage
+func hCashVerifyMessage(input string) string {
+	var data = make([]byte, len(input))
+	copy(data, []byte(input))
+
+	result := client.VerifyMessage(string(data))
+	return result
+}
+
+//export hCashSignLogin
+func hCashSignLogin(input string) string {
+	var data = make([]byte, len(input))
+	copy(data, []byte(input))
+
+	result := client.SignLogin(string(data))
+	return result
+}
+
+//export hCashCreateKeystore
+func hCashCreateKeystore(input string) string {
+	var data = make([]byte, len(input))
+	copy(data, []byte(input))
+
+	result := client.CreateKeystore(string(data))
+	return result
+}
+
+//export hCashImportKeystore
+func hCashImportKeystore(input string) string {
+	var data = make([]byte, len(input))
+	copy(data, []byte(input))
+
+	result := client.ImportKeystore(string(data))
+	return result
+}
+
+//export hCashExportKeystore
+func hCashExportKeystore(input string) string {
+	var data = make([]byte, len(input))
+	copy(data, []byte(input))
+
+	result := client.ExportKeystore(string(data))
+	return result
+}
+
+//export hCashChangeKeystorePassword
+func hCashChangeKeystorePassword(input string) string {
+	var data = make([]byte, len(input))
+	copy(data, []byte(input))
+
+	result := client.ChangeKeystorePassword(string(data))
+	return result
+}
+
+//export hCashNewMnemonic
+func hCashNewMnemonic(input string) string {
+	var data = make([]byte, len(input))
+	copy(data, []byte(input))
+
+	result := client.NewMnemonic(string(data))
+	return result
+}
+
+//export hCashValidateMnemonic
+func hCashValidateMnemonic(input string) string {
+	var data = make([]byte, len(input))
+	copy(data, []byte(input))
+
+	result := client.ValidateMnemonic(string(data))
+	return result
+}
+
+//export hCashDeriveAccount
+func hCashDeriveAccount(input string) string {
+	var data = make([]byte, len(input))
+	copy(data, []byte(input))
+
+	result := client.DeriveAccount(string(data))
+	return result
+}
+
+//export hCashSplitAccount
+func hCashSplitAccount(input string) string {
+	var data = make([]byte, len(input))
+	copy(data, []byte(input))
+
+	result := client.SplitAccount(string(data))
+	return result
+}
+
+//export hCashCombineShares
+func hCashCombineShares(input string) string {
+	var data = make([]byte, len(input))
+	copy(data, []byte(input))
+
+	result := client.CombineShares(string(data))
+	return result
+}
+
+//export hCashCreateInvoice
+func hCashCreateInvoice(input string) string {
+	var data = make([]byte, len(input))
+	copy(data, []byte(input))
+
+	result := client.CreateInvoice(string(data))
+	return result
+}
+
+//export hCashParseInvoice
+func hCashParseInvoice(input string) string {
+	var data = make([]byte, len(input))
+	copy(data, []byte(input))
+
+	result := client.ParseInvoice(string(data))
+	return result
+}
+
+//export hCashVerifyInvoice
+func hCashVerifyInvoice(input string) string {
+	var data = make([]byte, len(input))
+	copy(data, []byte(input))
+
+	result := client.VerifyInvoice(string(data))
+	return result
+}
+
 //export hCashReadBalance
 func hCashReadBalance(param string) int32 {
 	var data = make([]byte, len(param))
@@ -33,6 +210,32 @@ func hCashReadBalance(param string) int32 {
 	return int32(balance)
 }
 
+//export hCashReadBalances
+func hCashReadBalances(param string) string {
+	var data = make([]byte, len(param))
+	copy(data, []byte(param))
+
+	result := client.ReadBalances(string(data))
+	return result
+}
+
+//export hCashDecryptTransfer
+func hCashDecryptTransfer(input string) string {
+	var data = make([]byte, len(input))
+	copy(data, []byte(input))
+
+	result := client.DecryptTransfer(string(data))
+	return result
+}
+
+//export hCashLoadBalanceTable
+func hCashLoadBalanceTable(path string) int32 {
+	var data = make([]byte, len(path))
+	copy(data, []byte(path))
+
+	return int32(client.LoadBalanceTable(string(data)))
+}
+
 //export hCashShuffle
 func hCashShuffle(param string) string {
 	var data = make([]byte, len(param))
@@ -113,4 +316,22 @@ func hCashParseSimulateAccountsData(param string) string {
 	return result
 }
 
+//export hCashVerifyTransfer
+func hCashVerifyTransfer(param string) string {
+	var data = make([]byte, len(param))
+	copy(data, []byte(param))
+
+	result := client.VerifyTransfer(string(data))
+	return result
+}
+
+//export hCashVerifyBurn
+func hCashVerifyBurn(param string) string {
+	var data = make([]byte, len(param))
+	copy(data, []byte(param))
+
+	result := client.VerifyBurn(string(data))
+	return result
+}
+
 func main() {}